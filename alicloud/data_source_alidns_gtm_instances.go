@@ -0,0 +1,340 @@
+package alicloud
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ datasource.DataSource              = &aliDnsGtmInstancesDataSource{}
+	_ datasource.DataSourceWithConfigure = &aliDnsGtmInstancesDataSource{}
+)
+
+func NewAliDnsGtmInstancesDataSource() datasource.DataSource {
+	return &aliDnsGtmInstancesDataSource{}
+}
+
+type aliDnsGtmInstancesDataSource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsGtmInstancesDataSourceModel struct {
+	NameRegex       types.String         `tfsdk:"name_regex"`
+	ResourceGroupId types.String         `tfsdk:"resource_group_id"`
+	IDs             types.List           `tfsdk:"ids"`
+	Instances       []*aliDnsGtmInstance `tfsdk:"instances"`
+}
+
+type aliDnsGtmInstance struct {
+	InstanceId           types.String   `tfsdk:"instance_id"`
+	InstanceName         types.String   `tfsdk:"instance_name"`
+	ResourceGroupId      types.String   `tfsdk:"resource_group_id"`
+	PaymentType          types.String   `tfsdk:"payment_type"`
+	PackageEdition       types.String   `tfsdk:"package_edition"`
+	StrategyMode         types.String   `tfsdk:"strategy_mode"`
+	Ttl                  types.Int64    `tfsdk:"ttl"`
+	CnameType            types.String   `tfsdk:"cname_type"`
+	PublicCnameMode      types.String   `tfsdk:"public_cname_mode"`
+	PublicRr             types.String   `tfsdk:"public_rr"`
+	PublicUserDomainName types.String   `tfsdk:"public_user_domain_name"`
+	PublicZoneName       types.String   `tfsdk:"public_zone_name"`
+	AlertGroup           types.List     `tfsdk:"alert_group"`
+	AlertConfig          []*alertConfig `tfsdk:"alert_config"`
+	SmsQuota             types.Int64    `tfsdk:"sms_quota"`
+	TaskQuota            types.Int64    `tfsdk:"task_quota"`
+	CreateTime           types.String   `tfsdk:"create_time"`
+	ExpireTime           types.String   `tfsdk:"expire_time"`
+	ExpireTimestamp      types.Int64    `tfsdk:"expire_timestamp"`
+}
+
+func (d *aliDnsGtmInstancesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_gtm_instances"
+}
+
+func (d *aliDnsGtmInstancesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the Global Traffic Manager instances of the current AliCloud user.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "A regex string to filter results by instance name.",
+				Optional:    true,
+			},
+			"resource_group_id": schema.StringAttribute{
+				Description: "The ID of the resource group to filter results by.",
+				Optional:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "A list of GTM instance IDs.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"instances": schema.ListNestedAttribute{
+				Description: "A list of GTM instances.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"instance_id": schema.StringAttribute{
+							Description: "The ID of the instance.",
+							Computed:    true,
+						},
+						"instance_name": schema.StringAttribute{
+							Description: "The name of the instance.",
+							Computed:    true,
+						},
+						"resource_group_id": schema.StringAttribute{
+							Description: "The ID of the resource group.",
+							Computed:    true,
+						},
+						"payment_type": schema.StringAttribute{
+							Description: "The payment type of the instance.",
+							Computed:    true,
+						},
+						"package_edition": schema.StringAttribute{
+							Description: "The paid package version of the instance.",
+							Computed:    true,
+						},
+						"strategy_mode": schema.StringAttribute{
+							Description: "The type of the access policy, GEO or LATENCY.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "The global time to live in seconds.",
+							Computed:    true,
+						},
+						"cname_type": schema.StringAttribute{
+							Description: "The access type of the CNAME domain name.",
+							Computed:    true,
+						},
+						"public_cname_mode": schema.StringAttribute{
+							Description: "The Public Network domain name access method, CUSTOM or SYSTEM_ASSIGN.",
+							Computed:    true,
+						},
+						"public_rr": schema.StringAttribute{
+							Description: "The CNAME access domain name.",
+							Computed:    true,
+						},
+						"public_user_domain_name": schema.StringAttribute{
+							Description: "The business domain name that the user uses on the Internet.",
+							Computed:    true,
+						},
+						"public_zone_name": schema.StringAttribute{
+							Description: "The domain name that is used to access GTM over the Internet.",
+							Computed:    true,
+						},
+						"alert_group": schema.ListAttribute{
+							Description: "The alert groups.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"alert_config": schema.ListNestedAttribute{
+							Description: "The alert notification methods.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"notice_type": schema.StringAttribute{
+										Description: "The alarm event type.",
+										Computed:    true,
+									},
+									"dingtalk_notice": schema.BoolAttribute{
+										Description: "Whether DingTalk notifications are configured.",
+										Computed:    true,
+									},
+									"email_notice": schema.BoolAttribute{
+										Description: "Whether mail notifications are configured.",
+										Computed:    true,
+									},
+									"sms_notice": schema.BoolAttribute{
+										Description: "Whether SMS notifications are configured.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"sms_quota": schema.Int64Attribute{
+							Description: "The quota of SMS notifications.",
+							Computed:    true,
+						},
+						"task_quota": schema.Int64Attribute{
+							Description: "The quota of detection tasks.",
+							Computed:    true,
+						},
+						"create_time": schema.StringAttribute{
+							Description: "The time when the instance was created.",
+							Computed:    true,
+						},
+						"expire_time": schema.StringAttribute{
+							Description: "The time when the instance expires.",
+							Computed:    true,
+						},
+						"expire_timestamp": schema.Int64Attribute{
+							Description: "The timestamp in milliseconds when the instance expires.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *aliDnsGtmInstancesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (d *aliDnsGtmInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state aliDnsGtmInstancesDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !plan.NameRegex.IsNull() {
+		var err error
+		if nameRegex, err = regexp.Compile(plan.NameRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"[ERROR] Invalid Regex",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	gtmInstances, err := d.describeGtmInstances(plan.ResourceGroupId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Instances",
+			err.Error(),
+		)
+		return
+	}
+
+	state.NameRegex = plan.NameRegex
+	state.ResourceGroupId = plan.ResourceGroupId
+	state.Instances = make([]*aliDnsGtmInstance, 0)
+	ids := make([]attr.Value, 0)
+	for _, gtmInstance := range gtmInstances {
+		config := gtmInstance.Config
+		if config == nil {
+			config = &alicloudDnsClient.DescribeDnsGtmInstancesResponseBodyGtmInstancesConfig{}
+		}
+		if nameRegex != nil && !nameRegex.MatchString(tea.StringValue(config.InstanceName)) {
+			continue
+		}
+
+		alertGroups := make([]attr.Value, 0)
+		if config.AlertGroup != nil {
+			alertGroupList, err := convertJsonStringToListString(tea.StringValue(config.AlertGroup))
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"[ERROR] Invalid Alert Group",
+					err.Error(),
+				)
+				return
+			}
+			for _, alertGroup := range alertGroupList {
+				alertGroups = append(alertGroups, types.StringValue(alertGroup))
+			}
+		}
+
+		alertConfigs := make([]*alertConfig, 0)
+		for _, x := range config.AlertConfig {
+			alertConfigs = append(alertConfigs, &alertConfig{
+				NoticeType:     types.StringValue(tea.StringValue(x.NoticeType)),
+				DingtalkNotice: types.BoolValue(tea.StringValue(x.DingtalkNotice) == "true"),
+				EmailNotice:    types.BoolValue(tea.StringValue(x.EmailNotice) == "true"),
+				SmsNotice:      types.BoolValue(tea.StringValue(x.SmsNotice) == "true"),
+			})
+		}
+
+		instance := &aliDnsGtmInstance{
+			InstanceId:           types.StringValue(tea.StringValue(gtmInstance.InstanceId)),
+			InstanceName:         types.StringValue(tea.StringValue(config.InstanceName)),
+			ResourceGroupId:      types.StringValue(tea.StringValue(gtmInstance.ResourceGroupId)),
+			PaymentType:          types.StringValue(tea.StringValue(gtmInstance.PaymentType)),
+			PackageEdition:       types.StringValue(tea.StringValue(gtmInstance.VersionCode)),
+			StrategyMode:         types.StringValue(tea.StringValue(config.StrategyMode)),
+			Ttl:                  types.Int64Value(int64(tea.Int32Value(config.Ttl))),
+			CnameType:            types.StringValue(tea.StringValue(config.CnameType)),
+			PublicCnameMode:      types.StringValue(tea.StringValue(config.PublicCnameMode)),
+			PublicRr:             types.StringValue(tea.StringValue(config.PublicRr)),
+			PublicUserDomainName: types.StringValue(tea.StringValue(config.PublicUserDomainName)),
+			PublicZoneName:       types.StringValue(tea.StringValue(config.PublicZoneName)),
+			AlertGroup:           types.ListValueMust(types.StringType, alertGroups),
+			AlertConfig:          alertConfigs,
+			SmsQuota:             types.Int64Value(int64(tea.Int32Value(gtmInstance.SmsQuota))),
+			TaskQuota:            types.Int64Value(int64(tea.Int32Value(gtmInstance.TaskQuota))),
+			CreateTime:           types.StringValue(tea.StringValue(gtmInstance.CreateTime)),
+			ExpireTime:           types.StringValue(tea.StringValue(gtmInstance.ExpireTime)),
+			ExpireTimestamp:      types.Int64Value(tea.Int64Value(gtmInstance.ExpireTimestamp)),
+		}
+		state.Instances = append(state.Instances, instance)
+		ids = append(ids, instance.InstanceId)
+	}
+	state.IDs = types.ListValueMust(types.StringType, ids)
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// describeGtmInstances pages through all the GTM instances, in the resource
+// group if it is not empty.
+func (d *aliDnsGtmInstancesDataSource) describeGtmInstances(resourceGroupId string) ([]*alicloudDnsClient.DescribeDnsGtmInstancesResponseBodyGtmInstances, error) {
+	gtmInstances := make([]*alicloudDnsClient.DescribeDnsGtmInstancesResponseBodyGtmInstances, 0)
+
+	for pageNumber := int32(1); ; pageNumber++ {
+		var describeDnsGtmInstancesResponse *alicloudDnsClient.DescribeDnsGtmInstancesResponse
+		describeDnsGtmInstances := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			describeDnsGtmInstancesRequest := &alicloudDnsClient.DescribeDnsGtmInstancesRequest{
+				PageNumber: tea.Int32(pageNumber),
+				PageSize:   tea.Int32(100),
+			}
+			if resourceGroupId != "" {
+				describeDnsGtmInstancesRequest.ResourceGroupId = tea.String(resourceGroupId)
+			}
+
+			var err error
+			describeDnsGtmInstancesResponse, err = d.client.DescribeDnsGtmInstancesWithOptions(describeDnsGtmInstancesRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(describeDnsGtmInstances, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		body := describeDnsGtmInstancesResponse.Body
+		gtmInstances = append(gtmInstances, body.GtmInstances...)
+		if len(body.GtmInstances) == 0 || pageNumber >= tea.Int32Value(body.TotalPages) {
+			break
+		}
+	}
+
+	return gtmInstances, nil
+}
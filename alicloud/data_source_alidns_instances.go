@@ -0,0 +1,404 @@
+package alicloud
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	alicloudBaseClient "github.com/alibabacloud-go/bssopenapi-20171214/v3/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ datasource.DataSource              = &aliDnsInstancesDataSource{}
+	_ datasource.DataSourceWithConfigure = &aliDnsInstancesDataSource{}
+)
+
+func NewAliDnsInstancesDataSource() datasource.DataSource {
+	return &aliDnsInstancesDataSource{}
+}
+
+type aliDnsInstancesDataSource struct {
+	baseClient *alicloudBaseClient.Client
+	client     *alicloudDnsClient.Client
+}
+
+type aliDnsInstancesDataSourceModel struct {
+	VersionCode types.String      `tfsdk:"version_code"`
+	DomainName  types.String      `tfsdk:"domain_name"`
+	IDs         types.List        `tfsdk:"ids"`
+	Instances   []*aliDnsInstance `tfsdk:"instances"`
+}
+
+type aliDnsInstance struct {
+	InstanceId             types.String `tfsdk:"instance_id"`
+	VersionCode            types.String `tfsdk:"version_code"`
+	VersionName            types.String `tfsdk:"version_name"`
+	DnsSecurity            types.String `tfsdk:"dns_security"`
+	PaymentType            types.String `tfsdk:"payment_type"`
+	DomainNumbers          types.Int64  `tfsdk:"domain_numbers"`
+	BoundDomainCount       types.Int64  `tfsdk:"bound_domain_count"`
+	AvailableDomainNumbers types.Int64  `tfsdk:"available_domain_numbers"`
+	Domains                types.List   `tfsdk:"domains"`
+	StartTime              types.String `tfsdk:"start_time"`
+	EndTime                types.String `tfsdk:"end_time"`
+	EndTimestamp           types.Int64  `tfsdk:"end_timestamp"`
+	RenewalStatus          types.String `tfsdk:"renewal_status"`
+	RenewPeriod            types.Int64  `tfsdk:"renew_period"`
+}
+
+func (d *aliDnsInstancesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_instances"
+}
+
+func (d *aliDnsInstancesDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the Alidns instances of the current AliCloud user, " +
+			"with the usage of their domain quota.",
+		Attributes: map[string]schema.Attribute{
+			"version_code": schema.StringAttribute{
+				Description: "Paid package version to filter results by, such as version_enterprise_basic.",
+				Optional:    true,
+			},
+			"domain_name": schema.StringAttribute{
+				Description: "Domain name to filter results by the instance it is bound to.",
+				Optional:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "A list of Alidns instance IDs.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"instances": schema.ListNestedAttribute{
+				Description: "A list of Alidns instances.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"instance_id": schema.StringAttribute{
+							Description: "The ID of the instance.",
+							Computed:    true,
+						},
+						"version_code": schema.StringAttribute{
+							Description: "The paid package version of the instance.",
+							Computed:    true,
+						},
+						"version_name": schema.StringAttribute{
+							Description: "The name of the paid package version.",
+							Computed:    true,
+						},
+						"dns_security": schema.StringAttribute{
+							Description: "The DNS security level of the instance.",
+							Computed:    true,
+						},
+						"payment_type": schema.StringAttribute{
+							Description: "The payment type of the instance.",
+							Computed:    true,
+						},
+						"domain_numbers": schema.Int64Attribute{
+							Description: "The quota of domain names that can be bound to the instance.",
+							Computed:    true,
+						},
+						"bound_domain_count": schema.Int64Attribute{
+							Description: "The number of domain names bound to the instance.",
+							Computed:    true,
+						},
+						"available_domain_numbers": schema.Int64Attribute{
+							Description: "The number of domain names that can still be bound to the instance.",
+							Computed:    true,
+						},
+						"domains": schema.ListAttribute{
+							Description: "The domain names bound to the instance.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"start_time": schema.StringAttribute{
+							Description: "The time when the instance was purchased.",
+							Computed:    true,
+						},
+						"end_time": schema.StringAttribute{
+							Description: "The time when the instance expires.",
+							Computed:    true,
+						},
+						"end_timestamp": schema.Int64Attribute{
+							Description: "The timestamp in milliseconds when the instance expires.",
+							Computed:    true,
+						},
+						"renewal_status": schema.StringAttribute{
+							Description: "The renewal status of the instance, AutoRenewal, ManualRenewal or NotRenewal.",
+							Computed:    true,
+						},
+						"renew_period": schema.Int64Attribute{
+							Description: "The automatic renewal period in months, 0 if the instance is not automatically renewed.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *aliDnsInstancesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.baseClient = req.ProviderData.(alicloudClients).baseClient
+	d.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (d *aliDnsInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state aliDnsInstancesDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dnsProducts, err := d.describeDnsProductInstances(plan.VersionCode.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe DNS Product Instances",
+			err.Error(),
+		)
+		return
+	}
+
+	state.VersionCode = plan.VersionCode
+	state.DomainName = plan.DomainName
+	state.Instances = make([]*aliDnsInstance, 0)
+	ids := make([]attr.Value, 0)
+	for _, dnsProduct := range dnsProducts {
+		instanceId := tea.StringValue(dnsProduct.InstanceId)
+		domainNames, err := d.describeInstanceDomains(instanceId)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Describe Instance Domains",
+				err.Error(),
+			)
+			return
+		}
+
+		domains := make([]attr.Value, 0)
+		bound := false
+		for _, domainName := range domainNames {
+			domains = append(domains, types.StringValue(domainName))
+			bound = bound || strings.EqualFold(domainName, plan.DomainName.ValueString())
+		}
+		if !plan.DomainName.IsNull() && !bound {
+			continue
+		}
+
+		dnsSecurity := getAlidnsInstanceDnsSecurity(tea.StringValue(dnsProduct.DnsSecurity))
+		if dnsSecurity == "" {
+			dnsSecurity = tea.StringValue(dnsProduct.DnsSecurity)
+		}
+		domainNumbers := tea.Int64Value(dnsProduct.BindDomainCount)
+		boundDomainCount := tea.Int64Value(dnsProduct.BindDomainUsedCount)
+		availableDomainNumbers := domainNumbers - boundDomainCount
+		if availableDomainNumbers < 0 {
+			availableDomainNumbers = 0
+		}
+
+		instance := &aliDnsInstance{
+			InstanceId:             types.StringValue(instanceId),
+			VersionCode:            types.StringValue(tea.StringValue(dnsProduct.VersionCode)),
+			VersionName:            types.StringValue(tea.StringValue(dnsProduct.VersionName)),
+			DnsSecurity:            types.StringValue(dnsSecurity),
+			PaymentType:            types.StringValue(tea.StringValue(dnsProduct.PaymentType)),
+			DomainNumbers:          types.Int64Value(domainNumbers),
+			BoundDomainCount:       types.Int64Value(boundDomainCount),
+			AvailableDomainNumbers: types.Int64Value(availableDomainNumbers),
+			Domains:                types.ListValueMust(types.StringType, domains),
+			StartTime:              types.StringValue(tea.StringValue(dnsProduct.StartTime)),
+			EndTime:                types.StringValue(tea.StringValue(dnsProduct.EndTime)),
+			EndTimestamp:           types.Int64Value(tea.Int64Value(dnsProduct.EndTimestamp)),
+			RenewalStatus:          types.StringNull(),
+			RenewPeriod:            types.Int64Value(0),
+		}
+		state.Instances = append(state.Instances, instance)
+		ids = append(ids, instance.InstanceId)
+	}
+	state.IDs = types.ListValueMust(types.StringType, ids)
+
+	if len(state.Instances) > 0 {
+		instanceIds := make([]string, 0)
+		for _, instance := range state.Instances {
+			instanceIds = append(instanceIds, instance.InstanceId.ValueString())
+		}
+		billingInstances, err := d.queryAvailableInstances(instanceIds)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Query Available Instances",
+				err.Error(),
+			)
+			return
+		}
+
+		for _, instance := range state.Instances {
+			billingInstance, ok := billingInstances[instance.InstanceId.ValueString()]
+			if !ok {
+				continue
+			}
+			instance.RenewalStatus = types.StringValue(tea.StringValue(billingInstance.RenewStatus))
+			if tea.StringValue(billingInstance.RenewStatus) == "AutoRenewal" {
+				renewalDuration := int64(tea.Int32Value(billingInstance.RenewalDuration))
+				if tea.StringValue(billingInstance.RenewalDurationUnit) == "Y" {
+					renewalDuration *= 12
+				}
+				instance.RenewPeriod = types.Int64Value(renewalDuration)
+			}
+		}
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// describeDnsProductInstances pages through all the Alidns instances of the
+// version if it is not empty.
+func (d *aliDnsInstancesDataSource) describeDnsProductInstances(versionCode string) ([]*alicloudDnsClient.DescribeDnsProductInstancesResponseBodyDnsProductsDnsProduct, error) {
+	dnsProducts := make([]*alicloudDnsClient.DescribeDnsProductInstancesResponseBodyDnsProductsDnsProduct, 0)
+
+	for pageNumber := int64(1); ; pageNumber++ {
+		var describeDnsProductInstancesResponse *alicloudDnsClient.DescribeDnsProductInstancesResponse
+		describeDnsProductInstances := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			describeDnsProductInstancesRequest := &alicloudDnsClient.DescribeDnsProductInstancesRequest{
+				PageNumber: tea.Int64(pageNumber),
+				PageSize:   tea.Int64(100),
+			}
+			if versionCode != "" {
+				describeDnsProductInstancesRequest.VersionCode = tea.String(versionCode)
+			}
+
+			var err error
+			describeDnsProductInstancesResponse, err = d.client.DescribeDnsProductInstancesWithOptions(describeDnsProductInstancesRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(describeDnsProductInstances, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		body := describeDnsProductInstancesResponse.Body
+		if body.DnsProducts == nil || len(body.DnsProducts.DnsProduct) == 0 {
+			break
+		}
+		dnsProducts = append(dnsProducts, body.DnsProducts.DnsProduct...)
+		if int64(len(dnsProducts)) >= tea.Int64Value(body.TotalCount) {
+			break
+		}
+	}
+
+	return dnsProducts, nil
+}
+
+// describeInstanceDomains pages through the domain names bound to the
+// instance.
+func (d *aliDnsInstancesDataSource) describeInstanceDomains(instanceId string) ([]string, error) {
+	domainNames := make([]string, 0)
+
+	for pageNumber := int64(1); ; pageNumber++ {
+		var describeInstanceDomainsResponse *alicloudDnsClient.DescribeInstanceDomainsResponse
+		describeInstanceDomains := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			describeInstanceDomainsRequest := &alicloudDnsClient.DescribeInstanceDomainsRequest{
+				InstanceId: tea.String(instanceId),
+				PageNumber: tea.Int64(pageNumber),
+				PageSize:   tea.Int64(100),
+			}
+
+			var err error
+			describeInstanceDomainsResponse, err = d.client.DescribeInstanceDomainsWithOptions(describeInstanceDomainsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(describeInstanceDomains, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		body := describeInstanceDomainsResponse.Body
+		for _, instanceDomain := range body.InstanceDomains {
+			domainNames = append(domainNames, tea.StringValue(instanceDomain.DomainName))
+		}
+		if len(body.InstanceDomains) == 0 || int32(pageNumber) >= tea.Int32Value(body.TotalPages) {
+			break
+		}
+	}
+
+	return domainNames, nil
+}
+
+// queryAvailableInstances queries the billing information of the instances,
+// keyed by instance ID. The international billing endpoint is used if the
+// default one is not applicable to the account.
+func (d *aliDnsInstancesDataSource) queryAvailableInstances(instanceIds []string) (map[string]*alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList, error) {
+	billingInstances := make(map[string]*alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList)
+
+	for pageNum := int32(1); ; pageNum++ {
+		var queryAvailableInstancesResponse *alicloudBaseClient.QueryAvailableInstancesResponse
+		queryAvailableInstances := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			queryAvailableInstancesRequest := &alicloudBaseClient.QueryAvailableInstancesRequest{
+				InstanceIDs: tea.String(strings.Join(instanceIds, ",")),
+				ProductCode: tea.String("dns"),
+				PageNum:     tea.Int32(pageNum),
+				PageSize:    tea.Int32(100),
+			}
+
+			var err error
+			queryAvailableInstancesResponse, err = d.baseClient.QueryAvailableInstancesWithOptions(queryAvailableInstancesRequest, runtime)
+			if err != nil {
+				if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "NotApplicable" {
+					d.baseClient.Endpoint = tea.String("business.ap-southeast-1.aliyuncs.com")
+					return err
+				}
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(queryAvailableInstances, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		data := queryAvailableInstancesResponse.Body.Data
+		if data == nil || len(data.InstanceList) == 0 {
+			break
+		}
+		for _, billingInstance := range data.InstanceList {
+			billingInstances[tea.StringValue(billingInstance.InstanceID)] = billingInstance
+		}
+		if int32(len(billingInstances)) >= tea.Int32Value(data.TotalCount) {
+			break
+		}
+	}
+
+	return billingInstances, nil
+}
@@ -0,0 +1,369 @@
+package alicloud
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ datasource.DataSource              = &aliDnsRecordsDataSource{}
+	_ datasource.DataSourceWithConfigure = &aliDnsRecordsDataSource{}
+)
+
+func NewAliDnsRecordsDataSource() datasource.DataSource {
+	return &aliDnsRecordsDataSource{}
+}
+
+type aliDnsRecordsDataSource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsRecordsDataSourceModel struct {
+	DomainName   types.String    `tfsdk:"domain_name"`
+	RRRegex      types.String    `tfsdk:"rr_regex"`
+	Type         types.String    `tfsdk:"type"`
+	Value        types.String    `tfsdk:"value"`
+	Line         types.String    `tfsdk:"line"`
+	Status       types.String    `tfsdk:"status"`
+	WeightedOnly types.Bool      `tfsdk:"weighted_only"`
+	IDs          types.List      `tfsdk:"ids"`
+	Records      []*aliDnsRecord `tfsdk:"records"`
+}
+
+type aliDnsRecord struct {
+	RecordId   types.String `tfsdk:"record_id"`
+	RR         types.String `tfsdk:"rr"`
+	Type       types.String `tfsdk:"type"`
+	Value      types.String `tfsdk:"value"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+	Line       types.String `tfsdk:"line"`
+	Priority   types.Int64  `tfsdk:"priority"`
+	Status     types.String `tfsdk:"status"`
+	Locked     types.Bool   `tfsdk:"locked"`
+	Remark     types.String `tfsdk:"remark"`
+	Weight     types.Int64  `tfsdk:"weight"`
+	SlbEnabled types.Bool   `tfsdk:"slb_enabled"`
+}
+
+func (d *aliDnsRecordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_records"
+}
+
+func (d *aliDnsRecordsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the records of an Alidns domain.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "Domain Name of the records.",
+				Required:    true,
+			},
+			"rr_regex": schema.StringAttribute{
+				Description: "A regex string to filter results by Host Record (RR).",
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Record Type to filter results by.",
+				Optional:    true,
+			},
+			"value": schema.StringAttribute{
+				Description: "Record Value to filter results by.",
+				Optional:    true,
+			},
+			"line": schema.StringAttribute{
+				Description: "Resolution Line to filter results by.",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Record Status to filter results by. Valid values: ENABLE, DISABLE.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ENABLE", "DISABLE"),
+				},
+			},
+			"weighted_only": schema.BoolAttribute{
+				Description: "Only return the records of subdomains with weighted round robin enabled.",
+				Optional:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "List of the record IDs.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "A list of records.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"record_id": schema.StringAttribute{
+							Description: "Record Id.",
+							Computed:    true,
+						},
+						"rr": schema.StringAttribute{
+							Description: "Host Record (RR) of the record.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Record Type.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "Record Value.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Time to live of the record in seconds.",
+							Computed:    true,
+						},
+						"line": schema.StringAttribute{
+							Description: "Resolution Line of the record.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Priority of the MX record.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the record.",
+							Computed:    true,
+						},
+						"locked": schema.BoolAttribute{
+							Description: "Whether the record is locked.",
+							Computed:    true,
+						},
+						"remark": schema.StringAttribute{
+							Description: "Remark of the record.",
+							Computed:    true,
+						},
+						"weight": schema.Int64Attribute{
+							Description: "Weight of the record in the weighted round robin of the subdomain.",
+							Computed:    true,
+						},
+						"slb_enabled": schema.BoolAttribute{
+							Description: "Whether weighted round robin is enabled for the subdomain of the record.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *aliDnsRecordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (d *aliDnsRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state aliDnsRecordsDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var rrRegex *regexp.Regexp
+	if !plan.RRRegex.IsNull() {
+		var err error
+		if rrRegex, err = regexp.Compile(plan.RRRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rr_regex"),
+				"[ERROR] Invalid Regex",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Type, line, status and value are filtered by the API, the value only as
+	// a keyword which is matched exactly below.
+	describeDomainRecordsRequest := &alicloudDnsClient.DescribeDomainRecordsRequest{
+		DomainName: tea.String(plan.DomainName.ValueString()),
+	}
+	if !plan.Type.IsNull() {
+		describeDomainRecordsRequest.Type = tea.String(plan.Type.ValueString())
+	}
+	if !plan.Line.IsNull() {
+		describeDomainRecordsRequest.Line = tea.String(plan.Line.ValueString())
+	}
+	if !plan.Status.IsNull() {
+		describeDomainRecordsRequest.Status = tea.String(plan.Status.ValueString())
+	}
+	if !plan.Value.IsNull() {
+		describeDomainRecordsRequest.ValueKeyWord = tea.String(plan.Value.ValueString())
+	}
+
+	records, err := describeAliDnsDomainRecords(d.client, describeDomainRecordsRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain Records",
+			err.Error(),
+		)
+		return
+	}
+
+	slbSubDomains, err := describeAliDnsSLBSubDomains(d.client, plan.DomainName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe DNS SLB Subdomains",
+			err.Error(),
+		)
+		return
+	}
+
+	state.DomainName = plan.DomainName
+	state.RRRegex = plan.RRRegex
+	state.Type = plan.Type
+	state.Value = plan.Value
+	state.Line = plan.Line
+	state.Status = plan.Status
+	state.WeightedOnly = plan.WeightedOnly
+	state.Records = make([]*aliDnsRecord, 0)
+	ids := make([]attr.Value, 0)
+	for _, record := range records {
+		if rrRegex != nil && !rrRegex.MatchString(tea.StringValue(record.RR)) {
+			continue
+		}
+		if !plan.Value.IsNull() && tea.StringValue(record.Value) != plan.Value.ValueString() {
+			continue
+		}
+
+		subDomainName := getAliDnsSubDomainName(plan.DomainName.ValueString(), tea.StringValue(record.RR))
+		slbEnabled := slbSubDomains[subDomainName+"|"+tea.StringValue(record.Type)]
+		if plan.WeightedOnly.ValueBool() && !slbEnabled {
+			continue
+		}
+
+		aliDnsRecord := &aliDnsRecord{
+			RecordId:   types.StringValue(tea.StringValue(record.RecordId)),
+			RR:         types.StringValue(tea.StringValue(record.RR)),
+			Type:       types.StringValue(tea.StringValue(record.Type)),
+			Value:      types.StringValue(tea.StringValue(record.Value)),
+			TTL:        types.Int64Value(tea.Int64Value(record.TTL)),
+			Line:       types.StringValue(tea.StringValue(record.Line)),
+			Priority:   types.Int64Null(),
+			Status:     types.StringValue(tea.StringValue(record.Status)),
+			Locked:     types.BoolValue(tea.BoolValue(record.Locked)),
+			Remark:     types.StringValue(tea.StringValue(record.Remark)),
+			Weight:     types.Int64Null(),
+			SlbEnabled: types.BoolValue(slbEnabled),
+		}
+		if record.Priority != nil {
+			aliDnsRecord.Priority = types.Int64Value(tea.Int64Value(record.Priority))
+		}
+		if record.Weight != nil {
+			aliDnsRecord.Weight = types.Int64Value(int64(tea.Int32Value(record.Weight)))
+		}
+
+		state.Records = append(state.Records, aliDnsRecord)
+		ids = append(ids, aliDnsRecord.RecordId)
+	}
+	state.IDs = types.ListValueMust(types.StringType, ids)
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// describeAliDnsDomainRecords pages through all the records of the domain
+// matching the request.
+func describeAliDnsDomainRecords(client *alicloudDnsClient.Client, describeDomainRecordsRequest *alicloudDnsClient.DescribeDomainRecordsRequest) ([]*alicloudDnsClient.DescribeDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	records := make([]*alicloudDnsClient.DescribeDomainRecordsResponseBodyDomainRecordsRecord, 0)
+	describeDomainRecordsRequest.PageSize = tea.Int64(500)
+
+	for pageNumber := int64(1); ; pageNumber++ {
+		describeDomainRecordsRequest.PageNumber = tea.Int64(pageNumber)
+
+		var describeDomainRecordsResponse *alicloudDnsClient.DescribeDomainRecordsResponse
+		describeDomainRecords := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			var err error
+			describeDomainRecordsResponse, err = client.DescribeDomainRecordsWithOptions(describeDomainRecordsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(describeDomainRecords, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		if describeDomainRecordsResponse.Body.DomainRecords == nil ||
+			len(describeDomainRecordsResponse.Body.DomainRecords.Record) == 0 {
+			break
+		}
+		records = append(records, describeDomainRecordsResponse.Body.DomainRecords.Record...)
+		if int64(len(records)) >= tea.Int64Value(describeDomainRecordsResponse.Body.TotalCount) {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+// describeAliDnsSLBSubDomains returns whether weighted round robin is enabled
+// for every subdomain of the domain, keyed by "<subdomain>|<record type>".
+func describeAliDnsSLBSubDomains(client *alicloudDnsClient.Client, domainName string) (map[string]bool, error) {
+	slbSubDomains := make(map[string]bool)
+
+	for pageNumber := int64(1); ; pageNumber++ {
+		var describeDNSSLBSubDomainsResponse *alicloudDnsClient.DescribeDNSSLBSubDomainsResponse
+		describeDNSSLBSubDomains := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			describeDNSSLBSubDomainsRequest := &alicloudDnsClient.DescribeDNSSLBSubDomainsRequest{
+				DomainName: tea.String(domainName),
+				PageNumber: tea.Int64(pageNumber),
+				PageSize:   tea.Int64(100),
+			}
+
+			var err error
+			describeDNSSLBSubDomainsResponse, err = client.DescribeDNSSLBSubDomainsWithOptions(describeDNSSLBSubDomainsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(describeDNSSLBSubDomains, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		if describeDNSSLBSubDomainsResponse.Body.SlbSubDomains == nil ||
+			len(describeDNSSLBSubDomainsResponse.Body.SlbSubDomains.SlbSubDomain) == 0 {
+			break
+		}
+		for _, subDomain := range describeDNSSLBSubDomainsResponse.Body.SlbSubDomains.SlbSubDomain {
+			slbSubDomains[tea.StringValue(subDomain.SubDomain)+"|"+tea.StringValue(subDomain.Type)] = tea.BoolValue(subDomain.Open)
+		}
+		if pageNumber*100 >= tea.Int64Value(describeDNSSLBSubDomainsResponse.Body.TotalCount) {
+			break
+		}
+	}
+
+	return slbSubDomains, nil
+}
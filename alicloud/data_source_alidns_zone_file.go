@@ -0,0 +1,215 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// aliDnsZoneRecordTypes are the record types that can be expressed in a zone
+// file.
+var aliDnsZoneRecordTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CNAME": true,
+	"MX":    true,
+	"TXT":   true,
+	"SRV":   true,
+	"CAA":   true,
+	"NS":    true,
+}
+
+var (
+	_ datasource.DataSource              = &aliDnsZoneFileDataSource{}
+	_ datasource.DataSourceWithConfigure = &aliDnsZoneFileDataSource{}
+)
+
+func NewAliDnsZoneFileDataSource() datasource.DataSource {
+	return &aliDnsZoneFileDataSource{}
+}
+
+type aliDnsZoneFileDataSource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsZoneFileDataSourceModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	Content    types.String `tfsdk:"content"`
+}
+
+func (d *aliDnsZoneFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_zone_file"
+}
+
+func (d *aliDnsZoneFileDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source renders the current records of an Alidns domain as a BIND zone file. " +
+			"Only the enabled A, AAAA, CNAME, MX, TXT, SRV, CAA and NS records on the default line are rendered.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "Domain Name of the zone.",
+				Required:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the zone file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *aliDnsZoneFileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	d.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+func (d *aliDnsZoneFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state aliDnsZoneFileDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := describeAliDnsDomainRecords(d.client, &alicloudDnsClient.DescribeDomainRecordsRequest{
+		DomainName: tea.String(plan.DomainName.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain Records",
+			err.Error(),
+		)
+		return
+	}
+
+	zoneRecords := make([]*aliDnsZoneRecord, 0)
+	for _, record := range records {
+		if zoneRecord, ok := getAliDnsZoneRecord(record); ok {
+			zoneRecords = append(zoneRecords, zoneRecord)
+		}
+	}
+
+	state.DomainName = plan.DomainName
+	state.Content = types.StringValue(renderAliDnsZoneFile(plan.DomainName.ValueString(), zoneRecords))
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// getAliDnsZoneRecord converts an Alidns record into a zone file record. It
+// returns false if the record can not be expressed in a zone file, which is
+// a disabled record, a record not on the default line, or a record of an
+// unsupported type.
+func getAliDnsZoneRecord(record *alicloudDnsClient.DescribeDomainRecordsResponseBodyDomainRecordsRecord) (*aliDnsZoneRecord, bool) {
+	recordType := strings.ToUpper(tea.StringValue(record.Type))
+	if !aliDnsZoneRecordTypes[recordType] ||
+		tea.StringValue(record.Status) == "DISABLE" ||
+		tea.StringValue(record.Line) != "default" {
+		return nil, false
+	}
+
+	zoneRecord := &aliDnsZoneRecord{
+		RR:       strings.ToLower(tea.StringValue(record.RR)),
+		Type:     recordType,
+		Value:    tea.StringValue(record.Value),
+		TTL:      tea.Int64Value(record.TTL),
+		recordId: tea.StringValue(record.RecordId),
+	}
+	if recordType == "MX" {
+		zoneRecord.Priority = tea.Int64Value(record.Priority)
+	}
+	return zoneRecord, true
+}
+
+// renderAliDnsZoneFile renders the records of the domain as a BIND zone file,
+// sorted by host record, type and value.
+func renderAliDnsZoneFile(domainName string, records []*aliDnsZoneRecord) string {
+	domainName = strings.TrimSuffix(domainName, ".")
+	sortedRecords := make([]*aliDnsZoneRecord, len(records))
+	copy(sortedRecords, records)
+	sort.SliceStable(sortedRecords, func(i, j int) bool {
+		a, b := sortedRecords[i], sortedRecords[j]
+		if a.RR != b.RR {
+			// The records of the domain itself come first.
+			if a.RR == "@" || b.RR == "@" {
+				return a.RR == "@"
+			}
+			return a.RR < b.RR
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Value < b.Value
+	})
+
+	content := &strings.Builder{}
+	fmt.Fprintf(content, "$ORIGIN %s.\n", domainName)
+	fmt.Fprintf(content, "$TTL %d\n", defaultAliDnsZoneTTL)
+	for _, record := range sortedRecords {
+		fmt.Fprintf(content, "%s\t%d\tIN\t%s\t%s\n", record.RR, record.TTL, record.Type, renderZoneFileRdata(record))
+	}
+	return content.String()
+}
+
+// renderZoneFileRdata converts the value and the priority of an Alidns
+// record into the data of the record.
+func renderZoneFileRdata(record *aliDnsZoneRecord) string {
+	switch record.Type {
+	case "CNAME", "NS":
+		return record.Value + "."
+	case "MX":
+		return fmt.Sprintf("%d %s.", record.Priority, record.Value)
+	case "SRV":
+		fields := strings.Fields(record.Value)
+		if len(fields) == 4 {
+			fields[3] = strings.TrimSuffix(fields[3], ".") + "."
+		}
+		return strings.Join(fields, " ")
+	case "TXT":
+		// A character string is at most 255 bytes long, longer values are
+		// split into multiple strings.
+		value := record.Value
+		strs := make([]string, 0)
+		for len(value) > 255 {
+			strs = append(strs, escapeZoneFileString(value[:255]))
+			value = value[255:]
+		}
+		strs = append(strs, escapeZoneFileString(value))
+		return strings.Join(strs, " ")
+	default:
+		return record.Value
+	}
+}
+
+// escapeZoneFileString quotes the character string, escaping quotes,
+// backslashes and non-printable characters.
+func escapeZoneFileString(s string) string {
+	escaped := &strings.Builder{}
+	escaped.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			escaped.WriteByte('\\')
+			escaped.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(escaped, "\\%03d", c)
+		default:
+			escaped.WriteByte(c)
+		}
+	}
+	escaped.WriteByte('"')
+	return escaped.String()
+}
@@ -0,0 +1,394 @@
+package alicloud
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ datasource.DataSource              = &ramGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ramGroupsDataSource{}
+)
+
+func NewRamGroupsDataSource() datasource.DataSource {
+	return &ramGroupsDataSource{}
+}
+
+type ramGroupsDataSource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramGroupsDataSourceModel struct {
+	NameRegex             types.String `tfsdk:"name_regex"`
+	UserName              types.String `tfsdk:"user_name"`
+	PolicyName            types.String `tfsdk:"policy_name"`
+	PolicyType            types.String `tfsdk:"policy_type"`
+	ExpandPolicyDocuments types.Bool   `tfsdk:"expand_policy_documents"`
+	Groups                []*ramGroup  `tfsdk:"groups"`
+}
+
+type ramGroup struct {
+	GroupName        types.String         `tfsdk:"group_name"`
+	GroupId          types.String         `tfsdk:"group_id"`
+	Comments         types.String         `tfsdk:"comments"`
+	CreateDate       types.String         `tfsdk:"create_date"`
+	Users            types.List           `tfsdk:"users"`
+	AttachedPolicies []*ramAttachedPolicy `tfsdk:"attached_policies"`
+}
+
+func (d *ramGroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_groups"
+}
+
+func (d *ramGroupsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the RAM groups of the current AliCloud account, " +
+			"together with their members and attached policies.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "A regex to filter the groups by group name.",
+				Optional:    true,
+			},
+			"user_name": schema.StringAttribute{
+				Description: "Only the groups which the user is a member of are returned.",
+				Optional:    true,
+			},
+			"policy_name": schema.StringAttribute{
+				Description: "Only the groups which the policy is attached to are returned.",
+				Optional:    true,
+			},
+			"policy_type": schema.StringAttribute{
+				Description: "The type of policy_name. Valid values: System, Custom. " +
+					"Both types are searched if not set.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("System", "Custom"),
+					stringvalidator.AlsoRequires(path.MatchRoot("policy_name")),
+				},
+			},
+			"expand_policy_documents": schema.BoolAttribute{
+				Description: "Whether to return the document of the default version of every attached policy. " +
+					"Default to false.",
+				Optional: true,
+			},
+			"groups": schema.ListNestedAttribute{
+				Description: "A list of RAM groups.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group_name": schema.StringAttribute{
+							Description: "The name of the RAM group.",
+							Computed:    true,
+						},
+						"group_id": schema.StringAttribute{
+							Description: "The ID of the RAM group.",
+							Computed:    true,
+						},
+						"comments": schema.StringAttribute{
+							Description: "The comments of the RAM group.",
+							Computed:    true,
+						},
+						"create_date": schema.StringAttribute{
+							Description: "The creation date of the RAM group.",
+							Computed:    true,
+						},
+						"users": schema.ListAttribute{
+							Description: "The usernames of the members of the RAM group.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"attached_policies": ramAttachedPoliciesAttribute("RAM group"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ramGroupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (d *ramGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state ramGroupsDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+	state.Groups = []*ramGroup{}
+
+	var nameRegex *regexp.Regexp
+	if !(plan.NameRegex.IsNull() || plan.NameRegex.IsUnknown()) {
+		var err error
+		if nameRegex, err = regexp.Compile(plan.NameRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Regex",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	groups, err := d.listGroups()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to List Groups",
+			err.Error(),
+		)
+		return
+	}
+
+	var userGroups map[string]bool
+	if !(plan.UserName.IsNull() || plan.UserName.IsUnknown()) {
+		userGroups, err = d.listGroupsForUser(plan.UserName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to List Groups for User",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	var policyGroups map[string]bool
+	if !(plan.PolicyName.IsNull() || plan.PolicyName.IsUnknown()) {
+		_, policyGroups, err = listRamEntitiesForPolicy(d.client, plan.PolicyName.ValueString(), plan.PolicyType.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to List Entities for Policy",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	policyDocuments := newRamPolicyDocumentReader(d.client, plan.ExpandPolicyDocuments.ValueBool())
+	for _, group := range groups {
+		groupName := tea.StringValue(group.GroupName)
+		if nameRegex != nil && !nameRegex.MatchString(groupName) {
+			continue
+		}
+		if userGroups != nil && !userGroups[groupName] {
+			continue
+		}
+		if policyGroups != nil && !policyGroups[groupName] {
+			continue
+		}
+
+		users, err := d.listUsersForGroup(groupName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Users for Group",
+				err.Error(),
+			)
+			return
+		}
+		usersList, diags := types.ListValueFrom(ctx, types.StringType, users)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		attachedPolicies, err := d.listPoliciesForGroup(groupName, policyDocuments)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to List Policies for Group",
+				err.Error(),
+			)
+			return
+		}
+
+		state.Groups = append(state.Groups, &ramGroup{
+			GroupName:        types.StringValue(groupName),
+			GroupId:          types.StringValue(tea.StringValue(group.GroupId)),
+			Comments:         types.StringValue(tea.StringValue(group.Comments)),
+			CreateDate:       types.StringValue(tea.StringValue(group.CreateDate)),
+			Users:            usersList,
+			AttachedPolicies: attachedPolicies,
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *ramGroupsDataSource) listGroups() ([]*alicloudRamClient.ListGroupsResponseBodyGroupsGroup, error) {
+	groups := make([]*alicloudRamClient.ListGroupsResponseBodyGroupsGroup, 0)
+	var marker *string
+
+	for {
+		var listGroupsResponse *alicloudRamClient.ListGroupsResponse
+		listGroups := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			listGroupsRequest := &alicloudRamClient.ListGroupsRequest{
+				Marker:   marker,
+				MaxItems: tea.Int32(1000),
+			}
+
+			var err error
+			listGroupsResponse, err = d.client.ListGroupsWithOptions(listGroupsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(listGroups, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		if listGroupsResponse.Body.Groups != nil {
+			groups = append(groups, listGroupsResponse.Body.Groups.Group...)
+		}
+
+		if !tea.BoolValue(listGroupsResponse.Body.IsTruncated) {
+			break
+		}
+		marker = listGroupsResponse.Body.Marker
+	}
+
+	return groups, nil
+}
+
+func (d *ramGroupsDataSource) listGroupsForUser(userName string) (map[string]bool, error) {
+	groups := make(map[string]bool)
+
+	listGroupsForUser := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		listGroupsForUserRequest := &alicloudRamClient.ListGroupsForUserRequest{
+			UserName: tea.String(userName),
+		}
+
+		listGroupsForUserResponse, err := d.client.ListGroupsForUserWithOptions(listGroupsForUserRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		if listGroupsForUserResponse.Body.Groups != nil {
+			for _, group := range listGroupsForUserResponse.Body.Groups.Group {
+				groups[tea.StringValue(group.GroupName)] = true
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(listGroupsForUser, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (d *ramGroupsDataSource) listUsersForGroup(groupName string) ([]string, error) {
+	users := make([]string, 0)
+	var marker *string
+
+	for {
+		var listUsersForGroupResponse *alicloudRamClient.ListUsersForGroupResponse
+		listUsersForGroup := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			listUsersForGroupRequest := &alicloudRamClient.ListUsersForGroupRequest{
+				GroupName: tea.String(groupName),
+				Marker:    marker,
+				MaxItems:  tea.Int32(1000),
+			}
+
+			var err error
+			listUsersForGroupResponse, err = d.client.ListUsersForGroupWithOptions(listUsersForGroupRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(listUsersForGroup, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		if listUsersForGroupResponse.Body.Users != nil {
+			for _, user := range listUsersForGroupResponse.Body.Users.User {
+				users = append(users, tea.StringValue(user.UserName))
+			}
+		}
+
+		if !tea.BoolValue(listUsersForGroupResponse.Body.IsTruncated) {
+			break
+		}
+		marker = listUsersForGroupResponse.Body.Marker
+	}
+
+	return users, nil
+}
+
+func (d *ramGroupsDataSource) listPoliciesForGroup(groupName string, policyDocuments *ramPolicyDocumentReader) ([]*ramAttachedPolicy, error) {
+	attachedPolicies := make([]*ramAttachedPolicy, 0)
+
+	listPoliciesForGroup := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		listPoliciesForGroupRequest := &alicloudRamClient.ListPoliciesForGroupRequest{
+			GroupName: tea.String(groupName),
+		}
+
+		listPoliciesForGroupResponse, err := d.client.ListPoliciesForGroupWithOptions(listPoliciesForGroupRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		attachedPolicies = attachedPolicies[:0]
+		if listPoliciesForGroupResponse.Body.Policies != nil {
+			for _, policy := range listPoliciesForGroupResponse.Body.Policies.Policy {
+				attachedPolicies = append(attachedPolicies, &ramAttachedPolicy{
+					PolicyName: types.StringValue(tea.StringValue(policy.PolicyName)),
+					PolicyType: types.StringValue(tea.StringValue(policy.PolicyType)),
+				})
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(listPoliciesForGroup, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	if err := policyDocuments.expand(attachedPolicies); err != nil {
+		return nil, err
+	}
+
+	return attachedPolicies, nil
+}
@@ -0,0 +1,559 @@
+package alicloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource = &ramPolicyDocumentDataSource{}
+)
+
+func NewRamPolicyDocumentDataSource() datasource.DataSource {
+	return &ramPolicyDocumentDataSource{}
+}
+
+type ramPolicyDocumentDataSource struct{}
+
+type ramPolicyDocumentDataSourceModel struct {
+	Version                 types.String                  `tfsdk:"version"`
+	SourcePolicyDocuments   types.List                    `tfsdk:"source_policy_documents"`
+	OverridePolicyDocuments types.List                    `tfsdk:"override_policy_documents"`
+	Statement               []*ramPolicyDocumentStatement `tfsdk:"statement"`
+	Json                    types.String                  `tfsdk:"json"`
+	MinifiedJson            types.String                  `tfsdk:"minified_json"`
+}
+
+type ramPolicyDocumentStatement struct {
+	Sid          types.String                  `tfsdk:"sid"`
+	Effect       types.String                  `tfsdk:"effect"`
+	Actions      types.List                    `tfsdk:"actions"`
+	NotActions   types.List                    `tfsdk:"not_actions"`
+	Resources    types.List                    `tfsdk:"resources"`
+	NotResources types.List                    `tfsdk:"not_resources"`
+	Principals   []*ramPolicyDocumentPrincipal `tfsdk:"principals"`
+	Condition    []*ramPolicyDocumentCondition `tfsdk:"condition"`
+}
+
+type ramPolicyDocumentPrincipal struct {
+	Type        types.String `tfsdk:"type"`
+	Identifiers types.List   `tfsdk:"identifiers"`
+}
+
+type ramPolicyDocumentCondition struct {
+	Operator types.String `tfsdk:"operator"`
+	Variable types.String `tfsdk:"variable"`
+	Values   types.List   `tfsdk:"values"`
+}
+
+// ramPolicy is the JSON representation of a RAM policy document.
+type ramPolicy struct {
+	Version   string                `json:"Version"`
+	Statement []*ramPolicyStatement `json:"Statement"`
+}
+
+// ramPolicyStatement is the JSON representation of a RAM policy statement.
+// Sid is only used to merge statements, it is not part of the RAM policy
+// grammar and is never rendered.
+type ramPolicyStatement struct {
+	Sid         string                                    `json:"Sid,omitempty"`
+	Effect      string                                    `json:"Effect"`
+	Principal   map[string]ramPolicyStringList            `json:"Principal,omitempty"`
+	Action      ramPolicyStringList                       `json:"Action,omitempty"`
+	NotAction   ramPolicyStringList                       `json:"NotAction,omitempty"`
+	Resource    ramPolicyStringList                       `json:"Resource,omitempty"`
+	NotResource ramPolicyStringList                       `json:"NotResource,omitempty"`
+	Condition   map[string]map[string]ramPolicyStringList `json:"Condition,omitempty"`
+}
+
+// ramPolicyStringList accepts both a single string and a list of strings, as
+// both forms are allowed by RAM for actions, resources and condition values.
+type ramPolicyStringList []string
+
+func (l *ramPolicyStringList) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*l = ramPolicyStringList{value}
+		return nil
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("expected a string or a list of strings, got %s", string(data))
+	}
+	*l = values
+	return nil
+}
+
+func (d *ramPolicyDocumentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_policy_document"
+}
+
+func (d *ramPolicyDocumentDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source generates a RAM policy document in JSON format for use with resources that expect policy documents.",
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				Description: "The version of the policy document. Valid value: 1. Default to 1.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("1"),
+				},
+			},
+			"source_policy_documents": schema.ListAttribute{
+				Description: "List of RAM policy documents that are merged together into the exported document. " +
+					"Statements defined in statement blocks replace the statements with the same sid.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(validRamPolicyDocument()),
+				},
+			},
+			"override_policy_documents": schema.ListAttribute{
+				Description: "List of RAM policy documents that are merged together into the exported document. " +
+					"Statements in these documents replace the statements with the same sid, " +
+					"statements without sid are appended.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(validRamPolicyDocument()),
+				},
+			},
+			"json": schema.StringAttribute{
+				Description: "The policy document in canonical JSON format.",
+				Computed:    true,
+			},
+			"minified_json": schema.StringAttribute{
+				Description: "The policy document in minified canonical JSON format.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"statement": schema.ListNestedBlock{
+				Description: "The statements of the policy document.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"sid": schema.StringAttribute{
+							Description: "The identifier of the statement, used to override statements " +
+								"from source_policy_documents. It is not rendered in the policy document.",
+							Optional: true,
+						},
+						"effect": schema.StringAttribute{
+							Description: "The effect of the statement. Valid values: Allow, Deny. Default to Allow.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("Allow", "Deny"),
+							},
+						},
+						"actions": schema.ListAttribute{
+							Description: "The actions that the statement allows or denies, e.g. ecs:DescribeInstances.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"not_actions": schema.ListAttribute{
+							Description: "The actions that the statement does not apply to.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"resources": schema.ListAttribute{
+							Description: "The resources that the statement applies to, e.g. acs:ecs:*:*:instance/*.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"not_resources": schema.ListAttribute{
+							Description: "The resources that the statement does not apply to.",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"principals": schema.ListNestedBlock{
+							Description: "The principals of the statement, only used in trust policies of RAM roles.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"type": schema.StringAttribute{
+										Description: "The type of the principal. Valid values: RAM, Service, Federated.",
+										Required:    true,
+										Validators: []validator.String{
+											stringvalidator.OneOf("RAM", "Service", "Federated"),
+										},
+									},
+									"identifiers": schema.ListAttribute{
+										Description: "The identifiers of the principal.",
+										Required:    true,
+										ElementType: types.StringType,
+									},
+								},
+							},
+						},
+						"condition": schema.ListNestedBlock{
+							Description: "The conditions of the statement.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"operator": schema.StringAttribute{
+										Description: "The condition operator, e.g. StringEquals, IpAddress, Bool.",
+										Required:    true,
+									},
+									"variable": schema.StringAttribute{
+										Description: "The condition key, e.g. acs:SourceIp.",
+										Required:    true,
+									},
+									"values": schema.ListAttribute{
+										Description: "The values to compare the condition key with.",
+										Required:    true,
+										ElementType: types.StringType,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ramPolicyDocumentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state ramPolicyDocumentDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	policy := &ramPolicy{
+		Version:   "1",
+		Statement: []*ramPolicyStatement{},
+	}
+	if !(plan.Version.IsNull() || plan.Version.IsUnknown()) {
+		policy.Version = plan.Version.ValueString()
+	}
+
+	// Merge source policy documents
+	var sourcePolicyDocuments []string
+	resp.Diagnostics.Append(plan.SourcePolicyDocuments.ElementsAs(ctx, &sourcePolicyDocuments, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, document := range sourcePolicyDocuments {
+		sourcePolicy, err := parseRamPolicy(document)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("source_policy_documents").AtListIndex(i),
+				"Invalid Source Policy Document",
+				err.Error(),
+			)
+			return
+		}
+		for _, statement := range sourcePolicy.Statement {
+			policy.mergeStatement(statement)
+		}
+	}
+
+	// Merge statement blocks
+	sids := make(map[string]bool)
+	for i, statementBlock := range plan.Statement {
+		statement, statementDiags := statementBlock.toRamPolicyStatement(ctx, path.Root("statement").AtListIndex(i))
+		resp.Diagnostics.Append(statementDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if statement.Sid != "" {
+			if sids[statement.Sid] {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("statement").AtListIndex(i).AtName("sid"),
+					"Duplicate Statement Sid",
+					fmt.Sprintf("Found duplicate sid (%s), sid must be unique across statement blocks.", statement.Sid),
+				)
+				return
+			}
+			sids[statement.Sid] = true
+		}
+		policy.mergeStatement(statement)
+	}
+
+	// Merge override policy documents
+	var overridePolicyDocuments []string
+	resp.Diagnostics.Append(plan.OverridePolicyDocuments.ElementsAs(ctx, &overridePolicyDocuments, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, document := range overridePolicyDocuments {
+		overridePolicy, err := parseRamPolicy(document)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("override_policy_documents").AtListIndex(i),
+				"Invalid Override Policy Document",
+				err.Error(),
+			)
+			return
+		}
+		for _, statement := range overridePolicy.Statement {
+			policy.mergeStatement(statement)
+		}
+	}
+
+	policyJson, err := policy.render("  ")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Render Policy Document",
+			err.Error(),
+		)
+		return
+	}
+	minifiedPolicyJson, err := policy.render("")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Render Policy Document",
+			err.Error(),
+		)
+		return
+	}
+
+	lintErrors, lintWarnings := lintRamPolicyDocument(minifiedPolicyJson)
+	for _, lintError := range lintErrors {
+		resp.Diagnostics.AddError(
+			"Invalid RAM Policy Document",
+			lintError,
+		)
+	}
+	for _, lintWarning := range lintWarnings {
+		resp.Diagnostics.AddWarning(
+			"Overly Permissive RAM Policy Document",
+			lintWarning,
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Json = types.StringValue(policyJson)
+	state.MinifiedJson = types.StringValue(minifiedPolicyJson)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (s *ramPolicyDocumentStatement) toRamPolicyStatement(ctx context.Context, statementPath path.Path) (*ramPolicyStatement, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	statement := &ramPolicyStatement{
+		Sid:    s.Sid.ValueString(),
+		Effect: "Allow",
+	}
+	if !(s.Effect.IsNull() || s.Effect.IsUnknown()) {
+		statement.Effect = s.Effect.ValueString()
+	}
+
+	diags.Append(s.Actions.ElementsAs(ctx, &statement.Action, false)...)
+	diags.Append(s.NotActions.ElementsAs(ctx, &statement.NotAction, false)...)
+	diags.Append(s.Resources.ElementsAs(ctx, &statement.Resource, false)...)
+	diags.Append(s.NotResources.ElementsAs(ctx, &statement.NotResource, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if len(statement.Action) > 0 && len(statement.NotAction) > 0 {
+		diags.AddAttributeError(
+			statementPath.AtName("actions"),
+			"Conflicting Statement Actions",
+			"Only one of actions or not_actions can be configured in a statement.",
+		)
+	}
+	if len(statement.Action) == 0 && len(statement.NotAction) == 0 {
+		diags.AddAttributeError(
+			statementPath.AtName("actions"),
+			"Missing Statement Actions",
+			"One of actions or not_actions must be configured in a statement.",
+		)
+	}
+	if len(statement.Resource) > 0 && len(statement.NotResource) > 0 {
+		diags.AddAttributeError(
+			statementPath.AtName("resources"),
+			"Conflicting Statement Resources",
+			"Only one of resources or not_resources can be configured in a statement.",
+		)
+	}
+
+	for _, principal := range s.Principals {
+		var identifiers []string
+		diags.Append(principal.Identifiers.ElementsAs(ctx, &identifiers, false)...)
+		if statement.Principal == nil {
+			statement.Principal = make(map[string]ramPolicyStringList)
+		}
+		principalType := principal.Type.ValueString()
+		statement.Principal[principalType] = append(statement.Principal[principalType], identifiers...)
+	}
+
+	for _, condition := range s.Condition {
+		var values []string
+		diags.Append(condition.Values.ElementsAs(ctx, &values, false)...)
+		if statement.Condition == nil {
+			statement.Condition = make(map[string]map[string]ramPolicyStringList)
+		}
+		operator := condition.Operator.ValueString()
+		if statement.Condition[operator] == nil {
+			statement.Condition[operator] = make(map[string]ramPolicyStringList)
+		}
+		variable := condition.Variable.ValueString()
+		statement.Condition[operator][variable] = append(statement.Condition[operator][variable], values...)
+	}
+
+	return statement, diags
+}
+
+// parseRamPolicy parses a RAM policy document in JSON format.
+func parseRamPolicy(document string) (*ramPolicy, error) {
+	policy := &ramPolicy{}
+	if err := json.Unmarshal([]byte(document), policy); err != nil {
+		return nil, err
+	}
+
+	for i, statement := range policy.Statement {
+		if statement == nil {
+			return nil, fmt.Errorf("statement %d of the policy document is empty", i)
+		}
+	}
+
+	return policy, nil
+}
+
+// ramPolicyDocumentsEquivalent returns whether both policy documents render
+// the same canonical JSON, so that reformatting done by AliCloud is not
+// reported as drift.
+func ramPolicyDocumentsEquivalent(document, otherDocument string) bool {
+	if document == otherDocument {
+		return true
+	}
+
+	policy, err := parseRamPolicy(document)
+	if err != nil {
+		return false
+	}
+	otherPolicy, err := parseRamPolicy(otherDocument)
+	if err != nil {
+		return false
+	}
+
+	policyJson, err := policy.render("")
+	if err != nil {
+		return false
+	}
+	otherPolicyJson, err := otherPolicy.render("")
+	if err != nil {
+		return false
+	}
+
+	return policyJson == otherPolicyJson
+}
+
+// mergeStatement replaces the statement with the same sid, or appends the
+// statement if it has no sid or no statement with the same sid is found.
+func (p *ramPolicy) mergeStatement(statement *ramPolicyStatement) {
+	if statement.Sid != "" {
+		for i, existing := range p.Statement {
+			if existing.Sid == statement.Sid {
+				p.Statement[i] = statement
+				return
+			}
+		}
+	}
+	p.Statement = append(p.Statement, statement)
+}
+
+// render returns the canonical JSON of the policy. The values of every list
+// are sorted and deduplicated, map keys are sorted by encoding/json and sid
+// is removed, so that the same permissions always render the same document.
+func (p *ramPolicy) render(indent string) (string, error) {
+	canonical := &ramPolicy{
+		Version:   p.Version,
+		Statement: make([]*ramPolicyStatement, 0, len(p.Statement)),
+	}
+
+	for _, statement := range p.Statement {
+		canonical.Statement = append(canonical.Statement, statement.canonical())
+	}
+
+	return marshalRamPolicyJson(canonical, indent)
+}
+
+// marshalRamPolicyJson encodes a policy or statement into JSON. HTML escaping
+// is disabled as characters such as & are valid in resource names and
+// condition values.
+func marshalRamPolicyJson(v interface{}, indent string) (string, error) {
+	policyBuffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(policyBuffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(policyBuffer.String(), "\n"), nil
+}
+
+// canonical returns the copy of the statement without sid, with the values of
+// every list sorted and deduplicated.
+func (s *ramPolicyStatement) canonical() *ramPolicyStatement {
+	canonicalStatement := &ramPolicyStatement{
+		Effect:      s.Effect,
+		Action:      s.Action.canonical(),
+		NotAction:   s.NotAction.canonical(),
+		Resource:    s.Resource.canonical(),
+		NotResource: s.NotResource.canonical(),
+	}
+	if len(s.Principal) > 0 {
+		canonicalStatement.Principal = make(map[string]ramPolicyStringList)
+		for principalType, identifiers := range s.Principal {
+			canonicalStatement.Principal[principalType] = identifiers.canonical()
+		}
+	}
+	if len(s.Condition) > 0 {
+		canonicalStatement.Condition = make(map[string]map[string]ramPolicyStringList)
+		for operator, conditions := range s.Condition {
+			canonicalStatement.Condition[operator] = make(map[string]ramPolicyStringList)
+			for variable, values := range conditions {
+				canonicalStatement.Condition[operator][variable] = values.canonical()
+			}
+		}
+	}
+
+	return canonicalStatement
+}
+
+// canonical returns the sorted and deduplicated copy of the list.
+func (l ramPolicyStringList) canonical() ramPolicyStringList {
+	if len(l) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(l))
+	seen := make(map[string]bool)
+	for _, value := range l {
+		if !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+
+	return values
+}
@@ -0,0 +1,504 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	ramPolicyDecisionAllow        = "Allow"
+	ramPolicyDecisionExplicitDeny = "ExplicitDeny"
+	ramPolicyDecisionImplicitDeny = "ImplicitDeny"
+)
+
+var (
+	_ datasource.DataSource = &ramPolicySimulationDataSource{}
+)
+
+func NewRamPolicySimulationDataSource() datasource.DataSource {
+	return &ramPolicySimulationDataSource{}
+}
+
+type ramPolicySimulationDataSource struct{}
+
+type ramPolicySimulationDataSourceModel struct {
+	PolicyDocuments types.List                    `tfsdk:"policy_documents"`
+	Request         []*ramPolicySimulationRequest `tfsdk:"request"`
+	Results         []*ramPolicySimulationResult  `tfsdk:"results"`
+	AllAllowed      types.Bool                    `tfsdk:"all_allowed"`
+}
+
+type ramPolicySimulationRequest struct {
+	Action   types.String                  `tfsdk:"action"`
+	Resource types.String                  `tfsdk:"resource"`
+	Context  []*ramPolicySimulationContext `tfsdk:"context"`
+}
+
+type ramPolicySimulationContext struct {
+	Key    types.String `tfsdk:"key"`
+	Values types.List   `tfsdk:"values"`
+}
+
+type ramPolicySimulationResult struct {
+	Action             types.String `tfsdk:"action"`
+	Resource           types.String `tfsdk:"resource"`
+	Decision           types.String `tfsdk:"decision"`
+	Allowed            types.Bool   `tfsdk:"allowed"`
+	MatchedPolicyIndex types.Int64  `tfsdk:"matched_policy_index"`
+	MatchedStatement   types.String `tfsdk:"matched_statement"`
+}
+
+func (d *ramPolicySimulationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_policy_simulation"
+}
+
+func (d *ramPolicySimulationDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source evaluates RAM policy documents against requests offline, " +
+			"without calling AliCloud APIs. An explicit Deny always wins over Allow, and a " +
+			"request that is not allowed by any statement is implicitly denied.",
+		Attributes: map[string]schema.Attribute{
+			"policy_documents": schema.ListAttribute{
+				Description: "List of RAM policy documents to evaluate, e.g. the policy documents " +
+					"of st-alicloud_ram_policy.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(validRamPolicyDocument()),
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "The decisions of the requests, in the same order as the request blocks.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "The action of the request.",
+							Computed:    true,
+						},
+						"resource": schema.StringAttribute{
+							Description: "The resource of the request.",
+							Computed:    true,
+						},
+						"decision": schema.StringAttribute{
+							Description: "The decision of the request. Valid values: Allow, ExplicitDeny, ImplicitDeny.",
+							Computed:    true,
+						},
+						"allowed": schema.BoolAttribute{
+							Description: "Whether the request is allowed.",
+							Computed:    true,
+						},
+						"matched_policy_index": schema.Int64Attribute{
+							Description: "The index in policy_documents of the policy which decides the " +
+								"request. Null if the request is implicitly denied.",
+							Computed: true,
+						},
+						"matched_statement": schema.StringAttribute{
+							Description: "The statement which decides the request in minified canonical " +
+								"JSON format. Null if the request is implicitly denied.",
+							Computed: true,
+						},
+					},
+				},
+			},
+			"all_allowed": schema.BoolAttribute{
+				Description: "Whether all the requests are allowed.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"request": schema.ListNestedBlock{
+				Description: "The requests to evaluate.",
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "The action of the request, e.g. ecs:DescribeInstances.",
+							Required:    true,
+						},
+						"resource": schema.StringAttribute{
+							Description: "The resource of the request, e.g. acs:ecs:cn-hongkong:123456:instance/i-abc.",
+							Required:    true,
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"context": schema.ListNestedBlock{
+							Description: "The condition keys of the request, used to evaluate the " +
+								"conditions of the statements. Conditions on missing keys are not met.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"key": schema.StringAttribute{
+										Description: "The condition key, e.g. acs:SourceIp.",
+										Required:    true,
+									},
+									"values": schema.ListAttribute{
+										Description: "The values of the condition key.",
+										Required:    true,
+										ElementType: types.StringType,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ramPolicySimulationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state ramPolicySimulationDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+
+	var policyDocuments []string
+	resp.Diagnostics.Append(plan.PolicyDocuments.ElementsAs(ctx, &policyDocuments, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policies := make([]*ramPolicy, 0, len(policyDocuments))
+	for i, document := range policyDocuments {
+		policy, err := parseRamPolicy(document)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("policy_documents").AtListIndex(i),
+				"Invalid Policy Document",
+				err.Error(),
+			)
+			return
+		}
+		policies = append(policies, policy)
+	}
+
+	state.Results = make([]*ramPolicySimulationResult, 0, len(plan.Request))
+	state.AllAllowed = types.BoolValue(true)
+	for i, request := range plan.Request {
+		requestContext := make(map[string][]string)
+		for _, requestContextKey := range request.Context {
+			var values []string
+			resp.Diagnostics.Append(requestContextKey.Values.ElementsAs(ctx, &values, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			// Condition keys are case-insensitive.
+			key := strings.ToLower(requestContextKey.Key.ValueString())
+			requestContext[key] = append(requestContext[key], values...)
+		}
+
+		decision, policyIndex, statement, err := evaluateRamPolicies(policies,
+			request.Action.ValueString(), request.Resource.ValueString(), requestContext)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request").AtListIndex(i),
+				"[ERROR] Failed to Evaluate Request",
+				err.Error(),
+			)
+			return
+		}
+
+		result := &ramPolicySimulationResult{
+			Action:             request.Action,
+			Resource:           request.Resource,
+			Decision:           types.StringValue(decision),
+			Allowed:            types.BoolValue(decision == ramPolicyDecisionAllow),
+			MatchedPolicyIndex: types.Int64Null(),
+			MatchedStatement:   types.StringNull(),
+		}
+		if statement != nil {
+			statementJson, err := marshalRamPolicyJson(statement.canonical(), "")
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"[ERROR] Failed to Render Policy Statement",
+					err.Error(),
+				)
+				return
+			}
+			result.MatchedPolicyIndex = types.Int64Value(int64(policyIndex))
+			result.MatchedStatement = types.StringValue(statementJson)
+		}
+		if decision != ramPolicyDecisionAllow {
+			state.AllAllowed = types.BoolValue(false)
+		}
+		state.Results = append(state.Results, result)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// evaluateRamPolicies evaluates a request against the policies. The first
+// matching Deny statement explicitly denies the request, otherwise the first
+// matching Allow statement allows it, otherwise it is implicitly denied. The
+// index of the policy and the statement which decide the request are returned,
+// the statement is nil if the request is implicitly denied.
+func evaluateRamPolicies(policies []*ramPolicy, action, resource string, requestContext map[string][]string) (
+	decision string, policyIndex int, matchedStatement *ramPolicyStatement, err error) {
+	decision = ramPolicyDecisionImplicitDeny
+
+	for i, policy := range policies {
+		for _, statement := range policy.Statement {
+			matched, err := statement.matchesRequest(action, resource, requestContext)
+			if err != nil {
+				return "", 0, nil, err
+			}
+			if !matched {
+				continue
+			}
+
+			if statement.Effect == "Deny" {
+				return ramPolicyDecisionExplicitDeny, i, statement, nil
+			}
+			if decision == ramPolicyDecisionImplicitDeny {
+				decision, policyIndex, matchedStatement = ramPolicyDecisionAllow, i, statement
+			}
+		}
+	}
+
+	return
+}
+
+// matchesRequest returns whether the action, resource and conditions of the
+// statement all match the request. A statement without Resource and
+// NotResource, e.g. a statement of a trust policy, matches any resource.
+func (s *ramPolicyStatement) matchesRequest(action, resource string, requestContext map[string][]string) (bool, error) {
+	// Actions are case-insensitive while resources are case-sensitive.
+	if len(s.Action) > 0 && !matchRamPolicyPatterns(s.Action, action, true) {
+		return false, nil
+	}
+	if len(s.NotAction) > 0 && matchRamPolicyPatterns(s.NotAction, action, true) {
+		return false, nil
+	}
+	if len(s.Resource) > 0 && !matchRamPolicyPatterns(s.Resource, resource, false) {
+		return false, nil
+	}
+	if len(s.NotResource) > 0 && matchRamPolicyPatterns(s.NotResource, resource, false) {
+		return false, nil
+	}
+
+	// Every operator and every condition key must be met, while only one of
+	// the values of a condition key must be met.
+	for operator, conditions := range s.Condition {
+		for key, values := range conditions {
+			met, err := evaluateRamPolicyCondition(operator, values, requestContext[strings.ToLower(key)])
+			if err != nil {
+				return false, fmt.Errorf("failed to evaluate condition %s on %s: %w", operator, key, err)
+			}
+			if !met {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// matchRamPolicyPatterns returns whether the value matches any of the
+// patterns, where * matches any sequence of characters and ? matches any
+// single character.
+func matchRamPolicyPatterns(patterns []string, value string, ignoreCase bool) bool {
+	for _, pattern := range patterns {
+		if matchRamPolicyPattern(pattern, value, ignoreCase) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchRamPolicyPattern(pattern, value string, ignoreCase bool) bool {
+	expression := regexp.QuoteMeta(pattern)
+	expression = strings.ReplaceAll(expression, `\*`, `.*`)
+	expression = strings.ReplaceAll(expression, `\?`, `.`)
+	expression = "^" + expression + "$"
+	if ignoreCase {
+		expression = "(?is)" + expression
+	} else {
+		expression = "(?s)" + expression
+	}
+
+	return regexp.MustCompile(expression).MatchString(value)
+}
+
+// evaluateRamPolicyCondition evaluates a condition operator on the values of a
+// condition key in the request. Without a prefix, the condition is met if any
+// request value satisfies the operator. With ForAnyValue: the same applies,
+// and with ForAllValues: every request value must satisfy the operator, which
+// is also met when the request has no value. Negated operators such as
+// StringNotEquals are satisfied by a request value matching none of the
+// policy values.
+func evaluateRamPolicyCondition(operator string, policyValues, requestValues []string) (bool, error) {
+	allValues := strings.HasPrefix(operator, "ForAllValues:")
+	baseOperator := strings.TrimPrefix(strings.TrimPrefix(operator, "ForAnyValue:"), "ForAllValues:")
+
+	compare, negated, err := getRamPolicyConditionComparator(baseOperator)
+	if err != nil {
+		return false, err
+	}
+
+	satisfies := func(requestValue string) (bool, error) {
+		for _, policyValue := range policyValues {
+			matched, err := compare(requestValue, policyValue)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return !negated, nil
+			}
+		}
+		return negated, nil
+	}
+
+	if allValues {
+		for _, requestValue := range requestValues {
+			met, err := satisfies(requestValue)
+			if err != nil || !met {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	for _, requestValue := range requestValues {
+		met, err := satisfies(requestValue)
+		if err != nil || met {
+			return met, err
+		}
+	}
+	return false, nil
+}
+
+// getRamPolicyConditionComparator returns the function which compares a
+// request value with a policy value for the condition operator, and whether
+// the result of the operator is the negation of the comparison.
+func getRamPolicyConditionComparator(operator string) (compare func(requestValue, policyValue string) (bool, error), negated bool, err error) {
+	stringEquals := func(requestValue, policyValue string) (bool, error) {
+		return requestValue == policyValue, nil
+	}
+	stringEqualsIgnoreCase := func(requestValue, policyValue string) (bool, error) {
+		return strings.EqualFold(requestValue, policyValue), nil
+	}
+	stringLike := func(requestValue, policyValue string) (bool, error) {
+		return matchRamPolicyPattern(policyValue, requestValue, false), nil
+	}
+	numeric := func(matches func(requestValue, policyValue float64) bool) func(string, string) (bool, error) {
+		return func(requestValue, policyValue string) (bool, error) {
+			policyNumber, err := strconv.ParseFloat(policyValue, 64)
+			if err != nil {
+				return false, fmt.Errorf("the policy value %s is not a number", policyValue)
+			}
+			requestNumber, err := strconv.ParseFloat(requestValue, 64)
+			if err != nil {
+				return false, nil
+			}
+			return matches(requestNumber, policyNumber), nil
+		}
+	}
+	date := func(matches func(requestValue, policyValue time.Time) bool) func(string, string) (bool, error) {
+		return func(requestValue, policyValue string) (bool, error) {
+			policyDate, err := time.Parse(time.RFC3339, policyValue)
+			if err != nil {
+				return false, fmt.Errorf("the policy value %s is not a date in RFC 3339 format", policyValue)
+			}
+			requestDate, err := time.Parse(time.RFC3339, requestValue)
+			if err != nil {
+				return false, nil
+			}
+			return matches(requestDate, policyDate), nil
+		}
+	}
+	boolean := func(requestValue, policyValue string) (bool, error) {
+		policyBool, err := strconv.ParseBool(policyValue)
+		if err != nil {
+			return false, fmt.Errorf("the policy value %s is not a boolean", policyValue)
+		}
+		requestBool, err := strconv.ParseBool(requestValue)
+		if err != nil {
+			return false, nil
+		}
+		return requestBool == policyBool, nil
+	}
+	ipAddress := func(requestValue, policyValue string) (bool, error) {
+		requestIp := net.ParseIP(requestValue)
+		if requestIp == nil {
+			return false, nil
+		}
+		if _, policyNetwork, err := net.ParseCIDR(policyValue); err == nil {
+			return policyNetwork.Contains(requestIp), nil
+		}
+		policyIp := net.ParseIP(policyValue)
+		if policyIp == nil {
+			return false, fmt.Errorf("the policy value %s is not an IP address or CIDR block", policyValue)
+		}
+		return policyIp.Equal(requestIp), nil
+	}
+
+	switch operator {
+	case "StringEquals":
+		return stringEquals, false, nil
+	case "StringNotEquals":
+		return stringEquals, true, nil
+	case "StringEqualsIgnoreCase":
+		return stringEqualsIgnoreCase, false, nil
+	case "StringNotEqualsIgnoreCase":
+		return stringEqualsIgnoreCase, true, nil
+	case "StringLike":
+		return stringLike, false, nil
+	case "StringNotLike":
+		return stringLike, true, nil
+	case "NumericEquals":
+		return numeric(func(r, p float64) bool { return r == p }), false, nil
+	case "NumericNotEquals":
+		return numeric(func(r, p float64) bool { return r == p }), true, nil
+	case "NumericLessThan":
+		return numeric(func(r, p float64) bool { return r < p }), false, nil
+	case "NumericLessThanEquals":
+		return numeric(func(r, p float64) bool { return r <= p }), false, nil
+	case "NumericGreaterThan":
+		return numeric(func(r, p float64) bool { return r > p }), false, nil
+	case "NumericGreaterThanEquals":
+		return numeric(func(r, p float64) bool { return r >= p }), false, nil
+	case "DateEquals":
+		return date(func(r, p time.Time) bool { return r.Equal(p) }), false, nil
+	case "DateNotEquals":
+		return date(func(r, p time.Time) bool { return r.Equal(p) }), true, nil
+	case "DateLessThan":
+		return date(func(r, p time.Time) bool { return r.Before(p) }), false, nil
+	case "DateLessThanEquals":
+		return date(func(r, p time.Time) bool { return !r.After(p) }), false, nil
+	case "DateGreaterThan":
+		return date(func(r, p time.Time) bool { return r.After(p) }), false, nil
+	case "DateGreaterThanEquals":
+		return date(func(r, p time.Time) bool { return !r.Before(p) }), false, nil
+	case "Bool":
+		return boolean, false, nil
+	case "IpAddress":
+		return ipAddress, false, nil
+	case "NotIpAddress":
+		return ipAddress, true, nil
+	}
+
+	return nil, false, fmt.Errorf("the condition operator %s is not supported", operator)
+}
@@ -0,0 +1,541 @@
+package alicloud
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ datasource.DataSource              = &ramUsersDataSource{}
+	_ datasource.DataSourceWithConfigure = &ramUsersDataSource{}
+)
+
+func NewRamUsersDataSource() datasource.DataSource {
+	return &ramUsersDataSource{}
+}
+
+type ramUsersDataSource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramUsersDataSourceModel struct {
+	NameRegex             types.String `tfsdk:"name_regex"`
+	GroupName             types.String `tfsdk:"group_name"`
+	PolicyName            types.String `tfsdk:"policy_name"`
+	PolicyType            types.String `tfsdk:"policy_type"`
+	ExpandPolicyDocuments types.Bool   `tfsdk:"expand_policy_documents"`
+	Users                 []*ramUser   `tfsdk:"users"`
+}
+
+type ramUser struct {
+	UserName         types.String         `tfsdk:"user_name"`
+	UserId           types.String         `tfsdk:"user_id"`
+	DisplayName      types.String         `tfsdk:"display_name"`
+	CreateDate       types.String         `tfsdk:"create_date"`
+	Groups           types.List           `tfsdk:"groups"`
+	AttachedPolicies []*ramAttachedPolicy `tfsdk:"attached_policies"`
+}
+
+type ramAttachedPolicy struct {
+	PolicyName     types.String `tfsdk:"policy_name"`
+	PolicyType     types.String `tfsdk:"policy_type"`
+	PolicyDocument types.String `tfsdk:"policy_document"`
+}
+
+func (d *ramUsersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_users"
+}
+
+func (d *ramUsersDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the RAM users of the current AliCloud account, " +
+			"together with their groups and attached policies.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "A regex to filter the users by username.",
+				Optional:    true,
+			},
+			"group_name": schema.StringAttribute{
+				Description: "Only the users which are members of the group are returned.",
+				Optional:    true,
+			},
+			"policy_name": schema.StringAttribute{
+				Description: "Only the users which the policy is directly attached to are returned.",
+				Optional:    true,
+			},
+			"policy_type": schema.StringAttribute{
+				Description: "The type of policy_name. Valid values: System, Custom. " +
+					"Both types are searched if not set.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("System", "Custom"),
+					stringvalidator.AlsoRequires(path.MatchRoot("policy_name")),
+				},
+			},
+			"expand_policy_documents": schema.BoolAttribute{
+				Description: "Whether to return the document of the default version of every attached policy. " +
+					"Default to false.",
+				Optional: true,
+			},
+			"users": schema.ListNestedAttribute{
+				Description: "A list of RAM users.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_name": schema.StringAttribute{
+							Description: "The username of the RAM user.",
+							Computed:    true,
+						},
+						"user_id": schema.StringAttribute{
+							Description: "The ID of the RAM user.",
+							Computed:    true,
+						},
+						"display_name": schema.StringAttribute{
+							Description: "The display name of the RAM user.",
+							Computed:    true,
+						},
+						"create_date": schema.StringAttribute{
+							Description: "The creation date of the RAM user.",
+							Computed:    true,
+						},
+						"groups": schema.ListAttribute{
+							Description: "The names of the groups the RAM user is a member of.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"attached_policies": ramAttachedPoliciesAttribute("RAM user"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ramUsersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (d *ramUsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan, state ramUsersDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state = plan
+	state.Users = []*ramUser{}
+
+	var nameRegex *regexp.Regexp
+	if !(plan.NameRegex.IsNull() || plan.NameRegex.IsUnknown()) {
+		var err error
+		if nameRegex, err = regexp.Compile(plan.NameRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Regex",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	users, err := d.listUsers()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to List Users",
+			err.Error(),
+		)
+		return
+	}
+
+	var groupMembers map[string]bool
+	if !(plan.GroupName.IsNull() || plan.GroupName.IsUnknown()) {
+		groupMembers, err = d.listUsersForGroup(plan.GroupName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Users for Group",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	var policyUsers map[string]bool
+	if !(plan.PolicyName.IsNull() || plan.PolicyName.IsUnknown()) {
+		policyUsers, _, err = listRamEntitiesForPolicy(d.client, plan.PolicyName.ValueString(), plan.PolicyType.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to List Entities for Policy",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	policyDocuments := newRamPolicyDocumentReader(d.client, plan.ExpandPolicyDocuments.ValueBool())
+	for _, user := range users {
+		userName := tea.StringValue(user.UserName)
+		if nameRegex != nil && !nameRegex.MatchString(userName) {
+			continue
+		}
+		if groupMembers != nil && !groupMembers[userName] {
+			continue
+		}
+		if policyUsers != nil && !policyUsers[userName] {
+			continue
+		}
+
+		groups, err := d.listGroupsForUser(userName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to List Groups for User",
+				err.Error(),
+			)
+			return
+		}
+		groupsList, diags := types.ListValueFrom(ctx, types.StringType, groups)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		attachedPolicies, err := d.listPoliciesForUser(userName, policyDocuments)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to List Policies for User",
+				err.Error(),
+			)
+			return
+		}
+
+		state.Users = append(state.Users, &ramUser{
+			UserName:         types.StringValue(userName),
+			UserId:           types.StringValue(tea.StringValue(user.UserId)),
+			DisplayName:      types.StringValue(tea.StringValue(user.DisplayName)),
+			CreateDate:       types.StringValue(tea.StringValue(user.CreateDate)),
+			Groups:           groupsList,
+			AttachedPolicies: attachedPolicies,
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (d *ramUsersDataSource) listUsers() ([]*alicloudRamClient.ListUsersResponseBodyUsersUser, error) {
+	users := make([]*alicloudRamClient.ListUsersResponseBodyUsersUser, 0)
+	var marker *string
+
+	for {
+		var listUsersResponse *alicloudRamClient.ListUsersResponse
+		listUsers := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			listUsersRequest := &alicloudRamClient.ListUsersRequest{
+				Marker:   marker,
+				MaxItems: tea.Int32(1000),
+			}
+
+			var err error
+			listUsersResponse, err = d.client.ListUsersWithOptions(listUsersRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(listUsers, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		if listUsersResponse.Body.Users != nil {
+			users = append(users, listUsersResponse.Body.Users.User...)
+		}
+
+		if !tea.BoolValue(listUsersResponse.Body.IsTruncated) {
+			break
+		}
+		marker = listUsersResponse.Body.Marker
+	}
+
+	return users, nil
+}
+
+func (d *ramUsersDataSource) listUsersForGroup(groupName string) (map[string]bool, error) {
+	members := make(map[string]bool)
+	var marker *string
+
+	for {
+		var listUsersForGroupResponse *alicloudRamClient.ListUsersForGroupResponse
+		listUsersForGroup := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			listUsersForGroupRequest := &alicloudRamClient.ListUsersForGroupRequest{
+				GroupName: tea.String(groupName),
+				Marker:    marker,
+				MaxItems:  tea.Int32(1000),
+			}
+
+			var err error
+			listUsersForGroupResponse, err = d.client.ListUsersForGroupWithOptions(listUsersForGroupRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(listUsersForGroup, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		if listUsersForGroupResponse.Body.Users != nil {
+			for _, user := range listUsersForGroupResponse.Body.Users.User {
+				members[tea.StringValue(user.UserName)] = true
+			}
+		}
+
+		if !tea.BoolValue(listUsersForGroupResponse.Body.IsTruncated) {
+			break
+		}
+		marker = listUsersForGroupResponse.Body.Marker
+	}
+
+	return members, nil
+}
+
+func (d *ramUsersDataSource) listGroupsForUser(userName string) ([]string, error) {
+	groups := make([]string, 0)
+
+	listGroupsForUser := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		listGroupsForUserRequest := &alicloudRamClient.ListGroupsForUserRequest{
+			UserName: tea.String(userName),
+		}
+
+		listGroupsForUserResponse, err := d.client.ListGroupsForUserWithOptions(listGroupsForUserRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		groups = groups[:0]
+		if listGroupsForUserResponse.Body.Groups != nil {
+			for _, group := range listGroupsForUserResponse.Body.Groups.Group {
+				groups = append(groups, tea.StringValue(group.GroupName))
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(listGroupsForUser, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (d *ramUsersDataSource) listPoliciesForUser(userName string, policyDocuments *ramPolicyDocumentReader) ([]*ramAttachedPolicy, error) {
+	attachedPolicies := make([]*ramAttachedPolicy, 0)
+
+	listPoliciesForUser := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		listPoliciesForUserRequest := &alicloudRamClient.ListPoliciesForUserRequest{
+			UserName: tea.String(userName),
+		}
+
+		listPoliciesForUserResponse, err := d.client.ListPoliciesForUserWithOptions(listPoliciesForUserRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		attachedPolicies = attachedPolicies[:0]
+		if listPoliciesForUserResponse.Body.Policies != nil {
+			for _, policy := range listPoliciesForUserResponse.Body.Policies.Policy {
+				attachedPolicies = append(attachedPolicies, &ramAttachedPolicy{
+					PolicyName: types.StringValue(tea.StringValue(policy.PolicyName)),
+					PolicyType: types.StringValue(tea.StringValue(policy.PolicyType)),
+				})
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(listPoliciesForUser, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	if err := policyDocuments.expand(attachedPolicies); err != nil {
+		return nil, err
+	}
+
+	return attachedPolicies, nil
+}
+
+// ramAttachedPoliciesAttribute returns the schema of the policies attached to
+// a RAM principal, which is shared by the RAM users and groups data sources.
+func ramAttachedPoliciesAttribute(principal string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: "The policies attached to the " + principal + ".",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"policy_name": schema.StringAttribute{
+					Description: "The name of the policy.",
+					Computed:    true,
+				},
+				"policy_type": schema.StringAttribute{
+					Description: "The type of the policy. Valid values: System, Custom.",
+					Computed:    true,
+				},
+				"policy_document": schema.StringAttribute{
+					Description: "The document of the default version of the policy. " +
+						"Null if expand_policy_documents is not enabled.",
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// listRamEntitiesForPolicy returns the names of the users and groups which
+// the policy is attached to. Both System and Custom policies are searched if
+// the policy type is empty.
+func listRamEntitiesForPolicy(client *alicloudRamClient.Client, policyName, policyType string) (users map[string]bool, groups map[string]bool, err error) {
+	users = make(map[string]bool)
+	groups = make(map[string]bool)
+
+	policyTypes := []string{policyType}
+	if policyType == "" {
+		policyTypes = []string{"Custom", "System"}
+	}
+
+	for _, policyType := range policyTypes {
+		listEntitiesForPolicy := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			listEntitiesForPolicyRequest := &alicloudRamClient.ListEntitiesForPolicyRequest{
+				PolicyName: tea.String(policyName),
+				PolicyType: tea.String(policyType),
+			}
+
+			listEntitiesForPolicyResponse, err := client.ListEntitiesForPolicyWithOptions(listEntitiesForPolicyRequest, runtime)
+			if err != nil {
+				// The policy does not exist with this type.
+				if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "EntityNotExist.Policy" {
+					return nil
+				}
+				return handleAPIError(err)
+			}
+
+			if listEntitiesForPolicyResponse.Body.Users != nil {
+				for _, user := range listEntitiesForPolicyResponse.Body.Users.User {
+					users[tea.StringValue(user.UserName)] = true
+				}
+			}
+			if listEntitiesForPolicyResponse.Body.Groups != nil {
+				for _, group := range listEntitiesForPolicyResponse.Body.Groups.Group {
+					groups[tea.StringValue(group.GroupName)] = true
+				}
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err = backoff.Retry(listEntitiesForPolicy, reconnectBackoff); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return
+}
+
+// ramPolicyDocumentReader reads the documents of the default version of
+// policies, each policy is only read once as the same policies are usually
+// attached to many principals.
+type ramPolicyDocumentReader struct {
+	client    *alicloudRamClient.Client
+	enabled   bool
+	documents map[string]string
+}
+
+func newRamPolicyDocumentReader(client *alicloudRamClient.Client, enabled bool) *ramPolicyDocumentReader {
+	return &ramPolicyDocumentReader{
+		client:    client,
+		enabled:   enabled,
+		documents: make(map[string]string),
+	}
+}
+
+// expand sets the policy documents of the attached policies, or sets them to
+// null if the reader is not enabled.
+func (r *ramPolicyDocumentReader) expand(attachedPolicies []*ramAttachedPolicy) error {
+	for _, attachedPolicy := range attachedPolicies {
+		if !r.enabled {
+			attachedPolicy.PolicyDocument = types.StringNull()
+			continue
+		}
+
+		policyName := attachedPolicy.PolicyName.ValueString()
+		policyType := attachedPolicy.PolicyType.ValueString()
+		key := policyType + " " + policyName
+		if _, ok := r.documents[key]; !ok {
+			getPolicy := func() error {
+				runtime := &util.RuntimeOptions{}
+
+				getPolicyRequest := &alicloudRamClient.GetPolicyRequest{
+					PolicyName: tea.String(policyName),
+					PolicyType: tea.String(policyType),
+				}
+
+				getPolicyResponse, err := r.client.GetPolicyWithOptions(getPolicyRequest, runtime)
+				if err != nil {
+					return handleAPIError(err)
+				}
+
+				if getPolicyResponse.Body.DefaultPolicyVersion != nil {
+					r.documents[key] = tea.StringValue(getPolicyResponse.Body.DefaultPolicyVersion.PolicyDocument)
+				}
+				return nil
+			}
+
+			reconnectBackoff := backoff.NewExponentialBackOff()
+			reconnectBackoff.MaxElapsedTime = 30 * time.Second
+			if err := backoff.Retry(getPolicy, reconnectBackoff); err != nil {
+				return err
+			}
+		}
+		attachedPolicy.PolicyDocument = types.StringValue(r.documents[key])
+	}
+
+	return nil
+}
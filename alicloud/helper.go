@@ -1,12 +1,21 @@
 package alicloud
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	alicloudOpenapiClient "github.com/alibabacloud-go/darabonba-openapi/v2/client"
 	"github.com/alibabacloud-go/tea/tea"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/crypto/openpgp"
+
+	// RIPEMD160 is the hash assumed by openpgp for keys without hash
+	// preferences, it must be registered for such keys to be usable.
+	_ "golang.org/x/crypto/ripemd160"
 )
 
 // Convert the result for an array and returns a Json string
@@ -41,6 +50,44 @@ func trimStringQuotes(input string) string {
 	return strings.TrimPrefix(strings.TrimSuffix(input, "\""), "\"")
 }
 
+// encryptWithPgpKey encrypts the plaintext with a PGP public key, which is
+// either ASCII armored or base64-encoded. The encrypted message is returned
+// base64-encoded together with the fingerprint of the key, it can be decrypted
+// with: base64 --decode | gpg --decrypt.
+func encryptWithPgpKey(publicKey string, plaintext string) (encrypted string, fingerprint string, err error) {
+	var entityList openpgp.EntityList
+	if strings.Contains(publicKey, "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+		entityList, err = openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	} else {
+		decodedPublicKey, decodeErr := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+		if decodeErr != nil {
+			return "", "", fmt.Errorf("the PGP key is neither ASCII armored nor base64-encoded: %w", decodeErr)
+		}
+		entityList, err = openpgp.ReadKeyRing(bytes.NewReader(decodedPublicKey))
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read the PGP key: %w", err)
+	}
+	if len(entityList) != 1 {
+		return "", "", fmt.Errorf("the PGP key must contain exactly one public key, got %d", len(entityList))
+	}
+
+	encryptedBuffer := new(bytes.Buffer)
+	writer, err := openpgp.Encrypt(encryptedBuffer, entityList, nil, nil, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt with the PGP key: %w", err)
+	}
+	if _, err := writer.Write([]byte(plaintext)); err != nil {
+		return "", "", fmt.Errorf("failed to encrypt with the PGP key: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", "", fmt.Errorf("failed to encrypt with the PGP key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(encryptedBuffer.Bytes()),
+		hex.EncodeToString(entityList[0].PrimaryKey.Fingerprint[:]), nil
+}
+
 func initNewClient(providerConfig *alicloudOpenapiClient.Client, planConfig *clientConfig) (initClient bool, clientConfig *alicloudOpenapiClient.Config, diag diag.Diagnostics) {
 	initClient = false
 	clientConfig = &alicloudOpenapiClient.Config{}
@@ -93,3 +140,39 @@ func initNewClient(providerConfig *alicloudOpenapiClient.Client, planConfig *cli
 
 	return
 }
+
+// getSubscriptionPeriods returns the purchase periods in months allowed for a
+// subscription instance, 1 to 9 months or 1 to 3 years.
+func getSubscriptionPeriods() []int64 {
+	return []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 12, 24, 36}
+}
+
+// getRenewalPeriods returns the auto renewal periods in months allowed for a
+// subscription instance, 1, 2, 3 or 6 months, or 1 to 3 years.
+func getRenewalPeriods() []int64 {
+	return []int64{1, 2, 3, 6, 12, 24, 36}
+}
+
+// getRenewalPeriodAndUnit converts the renewal period in months into the
+// period and unit of the renewal request, so that yearly renewals are billed
+// by year.
+func getRenewalPeriodAndUnit(months int64) (int32, string) {
+	if months >= 12 && months%12 == 0 {
+		return int32(months / 12), "Y"
+	}
+	return int32(months), "M"
+}
+
+// getOnDestroyRenewalStatus returns the renewal status to set on a
+// subscription instance when it is destroyed, or an empty string if the
+// renewal status is kept.
+func getOnDestroyRenewalStatus(onDestroy string) string {
+	switch onDestroy {
+	case "manual_renewal":
+		return "ManualRenewal"
+	case "keep":
+		return ""
+	default:
+		return "NotRenewal"
+	}
+}
@@ -39,6 +39,7 @@ type alicloudClients struct {
 	adbClient      *alicloudAdbClient.Client
 	emrClient      *alicloudEmrClient.Client
 	csClient       *alicloudCsClient.Client
+	pvtzClient     *pvtzClient
 }
 
 // Ensure the implementation satisfies the expected interfaces
@@ -341,6 +342,21 @@ func (p *alicloudProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	// AliCloud PVTZ Client
+	pvtzClientConfig := clientCredentialsConfig
+	pvtzClientConfig.Endpoint = tea.String("pvtz.aliyuncs.com")
+	pvtzClient, err := newPvtzClient(pvtzClientConfig)
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create AliCloud PVTZ API Client",
+			"An unexpected error occurred when creating the AliCloud PVTZ API client. "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"AliCloud PVTZ Client Error: "+err.Error(),
+		)
+		return
+	}
+
 	// AliCloud clients wrapper
 	alicloudClients := alicloudClients{
 		baseClient:     baseClient,
@@ -353,6 +369,7 @@ func (p *alicloudProvider) Configure(ctx context.Context, req provider.Configure
 		adbClient:      adbClient,
 		emrClient:      emrClient,
 		csClient:       csClient,
+		pvtzClient:     pvtzClient,
 	}
 
 	resp.DataSourceData = alicloudClients
@@ -366,14 +383,35 @@ func (p *alicloudProvider) DataSources(_ context.Context) []func() datasource.Da
 		NewDdosCooDomainResourcesDataSource,
 		NewSlbLoadBalancersDataSource,
 		NewCsUserKubeconfigDataSource,
+		NewRamPolicyDocumentDataSource,
+		NewRamPolicySimulationDataSource,
+		NewRamUsersDataSource,
+		NewRamGroupsDataSource,
+		NewAliDnsRecordsDataSource,
+		NewAliDnsZoneFileDataSource,
+		NewAliDnsGtmInstancesDataSource,
+		NewAliDnsInstancesDataSource,
 	}
 }
 
 func (p *alicloudProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewAliDnsRecordResource,
 		NewAliDnsRecordWeightResource,
+		NewAliDnsWeightedRecordSetResource,
+		NewAliDnsZoneResource,
+		NewAliDnsRecordBatchResource,
+		NewAliDnsDomainResource,
+		NewAliDnsDomainGroupResource,
+		NewAliDnsGtmAddressPoolResource,
+		NewAliDnsGtmAccessStrategyResource,
 		NewAliDnsGtmInstanceResource,
 		NewRamUserGroupAttachmentResource,
+		NewRamGroupMembersResource,
+		NewRamRoleResource,
+		NewRamAccessKeyRotationResource,
+		NewRamUserLoginProfileResource,
+		NewRamSecurityPreferenceResource,
 		NewRamPolicyResource,
 		NewCmsAlarmRuleResource,
 		NewAlidnsDomainAttachmentResource,
@@ -383,5 +421,8 @@ func (p *alicloudProvider) Resources(_ context.Context) []func() resource.Resour
 		NewAliadbResourceGroupBindResource,
 		NewEmrMetricAutoScalingRulesResource,
 		NewDdosCooWebAIProtectConfigResource,
+		NewPvtzZoneResource,
+		NewPvtzZoneRecordResource,
+		NewPvtzZoneVpcAttachmentResource,
 	}
 }
@@ -0,0 +1,262 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	alicloudOpenapiClient "github.com/alibabacloud-go/darabonba-openapi/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// pvtzClient calls the PrivateZone (PVTZ) 2018-01-01 API through the generic
+// OpenAPI client, as the PVTZ SDK is not a dependency of the provider. The
+// methods follow the naming of the AliCloud SDK clients, and the responses
+// only carry the fields used by the PVTZ resources.
+type pvtzClient struct {
+	*alicloudOpenapiClient.Client
+}
+
+type pvtzZone struct {
+	ZoneId          string `json:"ZoneId"`
+	ZoneName        string `json:"ZoneName"`
+	Remark          string `json:"Remark"`
+	ProxyPattern    string `json:"ProxyPattern"`
+	RecordCount     int64  `json:"RecordCount"`
+	ResourceGroupId string `json:"ResourceGroupId"`
+	BindVpcs        struct {
+		Vpc []*pvtzVpc `json:"Vpc"`
+	} `json:"BindVpcs"`
+}
+
+type pvtzVpc struct {
+	VpcId    string `json:"VpcId"`
+	RegionId string `json:"RegionId"`
+}
+
+type pvtzZoneRecord struct {
+	RecordId int64  `json:"RecordId"`
+	Rr       string `json:"Rr"`
+	Type     string `json:"Type"`
+	Value    string `json:"Value"`
+	Ttl      int64  `json:"Ttl"`
+	Priority int64  `json:"Priority"`
+	Weight   int64  `json:"Weight"`
+	Status   string `json:"Status"`
+	Remark   string `json:"Remark"`
+}
+
+type pvtzZoneRecordRequest struct {
+	Rr       string
+	Type     string
+	Value    string
+	Ttl      int64
+	Priority *int64
+	Weight   *int64
+}
+
+func newPvtzClient(config *alicloudOpenapiClient.Config) (*pvtzClient, error) {
+	client, err := alicloudOpenapiClient.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &pvtzClient{Client: client}, nil
+}
+
+// AddZone creates a private zone and returns its ID.
+func (client *pvtzClient) AddZone(zoneName, remark, proxyPattern, resourceGroupId string) (string, error) {
+	query := map[string]*string{
+		"ZoneName":     tea.String(zoneName),
+		"ProxyPattern": tea.String(proxyPattern),
+	}
+	if remark != "" {
+		query["Remark"] = tea.String(remark)
+	}
+	if resourceGroupId != "" {
+		query["ResourceGroupId"] = tea.String(resourceGroupId)
+	}
+
+	var body struct {
+		ZoneId string `json:"ZoneId"`
+	}
+	if err := client.callApi("AddZone", query, &body); err != nil {
+		return "", err
+	}
+	return body.ZoneId, nil
+}
+
+// DescribeZoneInfo returns the private zone, together with the VPCs bound to
+// it.
+func (client *pvtzClient) DescribeZoneInfo(zoneId string) (*pvtzZone, error) {
+	zone := &pvtzZone{}
+	if err := client.callApi("DescribeZoneInfo", map[string]*string{"ZoneId": tea.String(zoneId)}, zone); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// UpdateZoneRemark updates the remark of the private zone.
+func (client *pvtzClient) UpdateZoneRemark(zoneId, remark string) error {
+	return client.callApi("UpdateZoneRemark", map[string]*string{
+		"ZoneId": tea.String(zoneId),
+		"Remark": tea.String(remark),
+	}, nil)
+}
+
+// SetProxyPattern sets whether the unmatched subdomains of the private zone
+// are resolved recursively on the Internet.
+func (client *pvtzClient) SetProxyPattern(zoneId, proxyPattern string) error {
+	return client.callApi("SetProxyPattern", map[string]*string{
+		"ZoneId":       tea.String(zoneId),
+		"ProxyPattern": tea.String(proxyPattern),
+	}, nil)
+}
+
+// MoveResourceGroup moves the private zone to another resource group.
+func (client *pvtzClient) MoveResourceGroup(zoneId, resourceGroupId string) error {
+	return client.callApi("MoveResourceGroup", map[string]*string{
+		"ResourceId":         tea.String(zoneId),
+		"NewResourceGroupId": tea.String(resourceGroupId),
+	}, nil)
+}
+
+// DeleteZone deletes the private zone.
+func (client *pvtzClient) DeleteZone(zoneId string) error {
+	return client.callApi("DeleteZone", map[string]*string{"ZoneId": tea.String(zoneId)}, nil)
+}
+
+// BindZoneVpc replaces the VPCs bound to the private zone with the given
+// VPCs, all the VPCs are unbound if it is empty.
+func (client *pvtzClient) BindZoneVpc(zoneId string, vpcs []*pvtzVpc) error {
+	query := map[string]*string{"ZoneId": tea.String(zoneId)}
+	for i, vpc := range vpcs {
+		query[fmt.Sprintf("Vpcs.%d.VpcId", i+1)] = tea.String(vpc.VpcId)
+		query[fmt.Sprintf("Vpcs.%d.RegionId", i+1)] = tea.String(vpc.RegionId)
+	}
+	return client.callApi("BindZoneVpc", query, nil)
+}
+
+// AddZoneRecord adds a record to the private zone and returns its ID.
+func (client *pvtzClient) AddZoneRecord(zoneId string, record *pvtzZoneRecordRequest) (int64, error) {
+	query := record.query()
+	query["ZoneId"] = tea.String(zoneId)
+
+	var body struct {
+		RecordId int64 `json:"RecordId"`
+	}
+	if err := client.callApi("AddZoneRecord", query, &body); err != nil {
+		return 0, err
+	}
+	return body.RecordId, nil
+}
+
+// UpdateZoneRecord updates the record of a private zone.
+func (client *pvtzClient) UpdateZoneRecord(recordId int64, record *pvtzZoneRecordRequest) error {
+	query := record.query()
+	query["RecordId"] = tea.String(strconv.FormatInt(recordId, 10))
+	return client.callApi("UpdateZoneRecord", query, nil)
+}
+
+// UpdateRecordRemark updates the remark of the record.
+func (client *pvtzClient) UpdateRecordRemark(recordId int64, remark string) error {
+	return client.callApi("UpdateRecordRemark", map[string]*string{
+		"RecordId": tea.String(strconv.FormatInt(recordId, 10)),
+		"Remark":   tea.String(remark),
+	}, nil)
+}
+
+// SetZoneRecordStatus enables or disables the record, the status is either
+// ENABLE or DISABLE.
+func (client *pvtzClient) SetZoneRecordStatus(recordId int64, status string) error {
+	return client.callApi("SetZoneRecordStatus", map[string]*string{
+		"RecordId": tea.String(strconv.FormatInt(recordId, 10)),
+		"Status":   tea.String(status),
+	}, nil)
+}
+
+// DeleteZoneRecord deletes the record.
+func (client *pvtzClient) DeleteZoneRecord(recordId int64) error {
+	return client.callApi("DeleteZoneRecord", map[string]*string{
+		"RecordId": tea.String(strconv.FormatInt(recordId, 10)),
+	}, nil)
+}
+
+// DescribeZoneRecords pages through all the records of the private zone.
+func (client *pvtzClient) DescribeZoneRecords(zoneId string) ([]*pvtzZoneRecord, error) {
+	records := make([]*pvtzZoneRecord, 0)
+
+	for pageNumber := 1; ; pageNumber++ {
+		var body struct {
+			Records struct {
+				Record []*pvtzZoneRecord `json:"Record"`
+			} `json:"Records"`
+			TotalPages int `json:"TotalPages"`
+		}
+		if err := client.callApi("DescribeZoneRecords", map[string]*string{
+			"ZoneId":     tea.String(zoneId),
+			"PageNumber": tea.String(strconv.Itoa(pageNumber)),
+			"PageSize":   tea.String("100"),
+		}, &body); err != nil {
+			return nil, err
+		}
+
+		records = append(records, body.Records.Record...)
+		if len(body.Records.Record) == 0 || pageNumber >= body.TotalPages {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+// callApi calls the PVTZ API action, and decodes the response body into the
+// result if it is not nil. The API errors are returned as *tea.SDKError like
+// the SDK clients, so they can be handled by handleAPIError.
+func (client *pvtzClient) callApi(action string, query map[string]*string, result interface{}) error {
+	params := &alicloudOpenapiClient.Params{
+		Action:      tea.String(action),
+		Version:     tea.String("2018-01-01"),
+		Protocol:    tea.String("HTTPS"),
+		Pathname:    tea.String("/"),
+		Method:      tea.String("POST"),
+		AuthType:    tea.String("AK"),
+		Style:       tea.String("RPC"),
+		ReqBodyType: tea.String("formData"),
+		BodyType:    tea.String("json"),
+	}
+	request := &alicloudOpenapiClient.OpenApiRequest{
+		Query: query,
+	}
+	runtime := &util.RuntimeOptions{}
+
+	response, err := client.CallApi(params, request, runtime)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(response["body"])
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, result)
+}
+
+func (record *pvtzZoneRecordRequest) query() map[string]*string {
+	query := map[string]*string{
+		"Rr":    tea.String(record.Rr),
+		"Type":  tea.String(record.Type),
+		"Value": tea.String(record.Value),
+		"Ttl":   tea.String(strconv.FormatInt(record.Ttl, 10)),
+	}
+	if record.Priority != nil {
+		query["Priority"] = tea.String(strconv.FormatInt(*record.Priority, 10))
+	}
+	if record.Weight != nil {
+		query["Weight"] = tea.String(strconv.FormatInt(*record.Weight, 10))
+	}
+	return query
+}
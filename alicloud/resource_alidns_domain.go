@@ -0,0 +1,491 @@
+package alicloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &aliDnsDomainResource{}
+	_ resource.ResourceWithConfigure   = &aliDnsDomainResource{}
+	_ resource.ResourceWithImportState = &aliDnsDomainResource{}
+)
+
+func NewAliDnsDomainResource() resource.Resource {
+	return &aliDnsDomainResource{}
+}
+
+type aliDnsDomainResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsDomainResourceModel struct {
+	DomainName      types.String `tfsdk:"domain_name"`
+	GroupId         types.String `tfsdk:"group_id"`
+	ResourceGroupId types.String `tfsdk:"resource_group_id"`
+	Remark          types.String `tfsdk:"remark"`
+	DomainId        types.String `tfsdk:"domain_id"`
+	GroupName       types.String `tfsdk:"group_name"`
+	InstanceId      types.String `tfsdk:"instance_id"`
+	DnsServers      types.List   `tfsdk:"dns_servers"`
+	DnsStatus       types.String `tfsdk:"dns_status"`
+}
+
+// Metadata returns the resource DNS domain type name.
+func (r *aliDnsDomainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_domain"
+}
+
+// Schema defines the schema for the DNS domain resource.
+func (r *aliDnsDomainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alidns domain resource, which adds a domain to Alidns. The domain can be bound " +
+			"to an Alidns instance with st-alicloud_alidns_domain_attachment.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "Domain Name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Description: "ID of the domain group of the domain. Default to the default group.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"resource_group_id": schema.StringAttribute{
+				Description: "ID of the resource group of the domain. Default to the default resource group.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"remark": schema.StringAttribute{
+				Description: "Remark of the domain.",
+				Optional:    true,
+			},
+			"domain_id": schema.StringAttribute{
+				Description: "Domain ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_name": schema.StringAttribute{
+				Description: "Name of the domain group of the domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"instance_id": schema.StringAttribute{
+				Description: "ID of the Alidns instance the domain is bound to, which is managed by " +
+					"st-alicloud_alidns_domain_attachment.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dns_servers": schema.ListAttribute{
+				Description: "The DNS servers assigned by Alidns, to be set at the registrar of the domain.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dns_status": schema.StringAttribute{
+				Description: "Whether the domain is resolved by Alidns. Valid values: ALL_ALIDNS when all the DNS " +
+					"servers of the domain are Alidns, INCLUDE_ALIDNS when some are, NOT_ALIDNS when none is.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aliDnsDomainResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+// Create a new DNS domain resource
+func (r *aliDnsDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan *aliDnsDomainResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addDomain := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		addDomainRequest := &alicloudDnsClient.AddDomainRequest{
+			DomainName: tea.String(plan.DomainName.ValueString()),
+		}
+		if !plan.GroupId.IsUnknown() && !plan.GroupId.IsNull() {
+			addDomainRequest.GroupId = tea.String(plan.GroupId.ValueString())
+		}
+		if !plan.ResourceGroupId.IsUnknown() && !plan.ResourceGroupId.IsNull() {
+			addDomainRequest.ResourceGroupId = tea.String(plan.ResourceGroupId.ValueString())
+		}
+
+		if _, err := r.client.AddDomainWithOptions(addDomainRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(addDomain, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add Domain",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &aliDnsDomainResourceModel{
+		DomainName: plan.DomainName,
+		Remark:     types.StringNull(),
+		DnsServers: types.ListNull(types.StringType),
+	}
+	if err := r.readDomain(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain",
+			err.Error(),
+		)
+	}
+
+	// Set the state once the domain is added, so the domain is not orphaned
+	// if it fails to be read or the remark fails to be updated.
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Remark.IsNull() {
+		if err := r.updateRemark(plan.DomainName.ValueString(), plan.Remark.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update Domain Remark",
+				err.Error(),
+			)
+			return
+		}
+		state.Remark = plan.Remark
+
+		// Set state to fully populated data
+		setStateDiags = resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(setStateDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+}
+
+// Read DNS domain resource information
+func (r *aliDnsDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state *aliDnsDomainResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.readDomain(state); err != nil {
+		if isAliDnsDomainNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set refreshed state
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the DNS domain resource and sets the updated Terraform state on success.
+func (r *aliDnsDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *aliDnsDomainResourceModel
+
+	// Retrieve values from plan
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.DomainName.ValueString()
+
+	if !plan.GroupId.IsUnknown() && !plan.GroupId.Equal(state.GroupId) {
+		changeDomainGroup := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			changeDomainGroupRequest := &alicloudDnsClient.ChangeDomainGroupRequest{
+				DomainName: tea.String(domainName),
+				GroupId:    tea.String(plan.GroupId.ValueString()),
+			}
+			if _, err := r.client.ChangeDomainGroupWithOptions(changeDomainGroupRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(changeDomainGroup, reconnectBackoff); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Change Domain Group",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	if !plan.ResourceGroupId.IsUnknown() && !plan.ResourceGroupId.Equal(state.ResourceGroupId) {
+		moveDomainResourceGroup := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			moveDomainResourceGroupRequest := &alicloudDnsClient.MoveDomainResourceGroupRequest{
+				ResourceId:         tea.String(domainName),
+				NewResourceGroupId: tea.String(plan.ResourceGroupId.ValueString()),
+			}
+			if _, err := r.client.MoveDomainResourceGroupWithOptions(moveDomainResourceGroupRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(moveDomainResourceGroup, reconnectBackoff); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Move Domain Resource Group",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	if !plan.Remark.Equal(state.Remark) {
+		if err := r.updateRemark(domainName, plan.Remark.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update Domain Remark",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	state.Remark = plan.Remark
+	if err := r.readDomain(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state to plan data
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the DNS domain resource and removes the Terraform state on success.
+func (r *aliDnsDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state *aliDnsDomainResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteDomain := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteDomainRequest := &alicloudDnsClient.DeleteDomainRequest{
+			DomainName: tea.String(state.DomainName.ValueString()),
+		}
+		if _, err := r.client.DeleteDomainWithOptions(deleteDomainRequest, runtime); err != nil {
+			if isAliDnsDomainNotFoundError(err) {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteDomain, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete Domain",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *aliDnsDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import domain name and save to domain_name attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+}
+
+// readDomain refreshes the state with the domain information and the DNS
+// servers status.
+func (r *aliDnsDomainResource) readDomain(state *aliDnsDomainResourceModel) error {
+	var describeDomainInfoResponse *alicloudDnsClient.DescribeDomainInfoResponse
+	describeDomainInfo := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDomainInfoRequest := &alicloudDnsClient.DescribeDomainInfoRequest{
+			DomainName: tea.String(state.DomainName.ValueString()),
+		}
+
+		var err error
+		describeDomainInfoResponse, err = r.client.DescribeDomainInfoWithOptions(describeDomainInfoRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	var describeDomainNsResponse *alicloudDnsClient.DescribeDomainNsResponse
+	describeDomainNs := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDomainNsRequest := &alicloudDnsClient.DescribeDomainNsRequest{
+			DomainName: tea.String(state.DomainName.ValueString()),
+		}
+
+		var err error
+		describeDomainNsResponse, err = r.client.DescribeDomainNsWithOptions(describeDomainNsRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDomainInfo, reconnectBackoff); err != nil {
+		return err
+	}
+	reconnectBackoff.Reset()
+	if err := backoff.Retry(describeDomainNs, reconnectBackoff); err != nil {
+		return err
+	}
+
+	domainInfo := describeDomainInfoResponse.Body
+	state.DomainId = types.StringValue(tea.StringValue(domainInfo.DomainId))
+	state.GroupId = types.StringValue(tea.StringValue(domainInfo.GroupId))
+	state.GroupName = types.StringValue(tea.StringValue(domainInfo.GroupName))
+	state.ResourceGroupId = types.StringValue(tea.StringValue(domainInfo.ResourceGroupId))
+	state.InstanceId = types.StringValue(tea.StringValue(domainInfo.InstanceId))
+	if !state.Remark.IsNull() || tea.StringValue(domainInfo.Remark) != "" {
+		state.Remark = types.StringValue(tea.StringValue(domainInfo.Remark))
+	}
+
+	dnsServers := make([]attr.Value, 0)
+	if domainInfo.DnsServers != nil {
+		for _, dnsServer := range domainInfo.DnsServers.DnsServer {
+			dnsServers = append(dnsServers, types.StringValue(tea.StringValue(dnsServer)))
+		}
+	}
+	state.DnsServers = types.ListValueMust(types.StringType, dnsServers)
+
+	switch domainNs := describeDomainNsResponse.Body; {
+	case tea.BoolValue(domainNs.AllAliDns):
+		state.DnsStatus = types.StringValue("ALL_ALIDNS")
+	case tea.BoolValue(domainNs.IncludeAliDns):
+		state.DnsStatus = types.StringValue("INCLUDE_ALIDNS")
+	default:
+		state.DnsStatus = types.StringValue("NOT_ALIDNS")
+	}
+	return nil
+}
+
+func (r *aliDnsDomainResource) updateRemark(domainName, remark string) error {
+	updateDomainRemark := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateDomainRemarkRequest := &alicloudDnsClient.UpdateDomainRemarkRequest{
+			DomainName: tea.String(domainName),
+			Remark:     tea.String(remark),
+		}
+		if _, err := r.client.UpdateDomainRemarkWithOptions(updateDomainRemarkRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateDomainRemark, reconnectBackoff)
+}
+
+// isAliDnsDomainNotFoundError returns whether the error is returned for a
+// domain that does not exist in Alidns.
+func isAliDnsDomainNotFoundError(err error) bool {
+	if _t, ok := err.(*tea.SDKError); ok {
+		switch tea.StringValue(_t.Code) {
+		case "InvalidDomainName.NoExist", "DomainNotBelongToUser":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,294 @@
+package alicloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &aliDnsDomainGroupResource{}
+	_ resource.ResourceWithConfigure   = &aliDnsDomainGroupResource{}
+	_ resource.ResourceWithImportState = &aliDnsDomainGroupResource{}
+)
+
+func NewAliDnsDomainGroupResource() resource.Resource {
+	return &aliDnsDomainGroupResource{}
+}
+
+type aliDnsDomainGroupResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsDomainGroupResourceModel struct {
+	GroupId     types.String `tfsdk:"group_id"`
+	GroupName   types.String `tfsdk:"group_name"`
+	DomainCount types.Int64  `tfsdk:"domain_count"`
+}
+
+// Metadata returns the resource DNS domain group type name.
+func (r *aliDnsDomainGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_domain_group"
+}
+
+// Schema defines the schema for the DNS domain group resource.
+func (r *aliDnsDomainGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alidns domain group resource.",
+		Attributes: map[string]schema.Attribute{
+			"group_id": schema.StringAttribute{
+				Description: "Domain Group ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_name": schema.StringAttribute{
+				Description: "Domain Group Name.",
+				Required:    true,
+			},
+			"domain_count": schema.Int64Attribute{
+				Description: "Number of domains in the group.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aliDnsDomainGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+// Create a new DNS domain group resource
+func (r *aliDnsDomainGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan *aliDnsDomainGroupResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var addDomainGroupResponse *alicloudDnsClient.AddDomainGroupResponse
+	addDomainGroup := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		addDomainGroupRequest := &alicloudDnsClient.AddDomainGroupRequest{
+			GroupName: tea.String(plan.GroupName.ValueString()),
+		}
+
+		var err error
+		addDomainGroupResponse, err = r.client.AddDomainGroupWithOptions(addDomainGroupRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(addDomainGroup, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add Domain Group",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &aliDnsDomainGroupResourceModel{
+		GroupId:     types.StringValue(tea.StringValue(addDomainGroupResponse.Body.GroupId)),
+		GroupName:   plan.GroupName,
+		DomainCount: types.Int64Value(0),
+	}
+
+	// Set state to fully populated data
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read DNS domain group resource information
+func (r *aliDnsDomainGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state *aliDnsDomainGroupResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainGroup, err := r.describeDomainGroup(state.GroupId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain Groups",
+			err.Error(),
+		)
+		return
+	}
+	if domainGroup == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.GroupName = types.StringValue(tea.StringValue(domainGroup.GroupName))
+	state.DomainCount = types.Int64Value(tea.Int64Value(domainGroup.DomainCount))
+
+	// Set refreshed state
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the DNS domain group resource and sets the updated Terraform state on success.
+func (r *aliDnsDomainGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *aliDnsDomainGroupResourceModel
+
+	// Retrieve values from plan
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateDomainGroup := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateDomainGroupRequest := &alicloudDnsClient.UpdateDomainGroupRequest{
+			GroupId:   tea.String(state.GroupId.ValueString()),
+			GroupName: tea.String(plan.GroupName.ValueString()),
+		}
+		if _, err := r.client.UpdateDomainGroupWithOptions(updateDomainGroupRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(updateDomainGroup, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update Domain Group",
+			err.Error(),
+		)
+		return
+	}
+
+	state.GroupName = plan.GroupName
+
+	// Set state to plan data
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the DNS domain group resource and removes the Terraform state on success.
+func (r *aliDnsDomainGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state *aliDnsDomainGroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteDomainGroup := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteDomainGroupRequest := &alicloudDnsClient.DeleteDomainGroupRequest{
+			GroupId: tea.String(state.GroupId.ValueString()),
+		}
+		if _, err := r.client.DeleteDomainGroupWithOptions(deleteDomainGroupRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteDomainGroup, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete Domain Group",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *aliDnsDomainGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import group ID and save to group_id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("group_id"), req, resp)
+}
+
+// describeDomainGroup looks up the domain group by ID, and returns nil if it
+// does not exist.
+func (r *aliDnsDomainGroupResource) describeDomainGroup(groupId string) (*alicloudDnsClient.DescribeDomainGroupsResponseBodyDomainGroupsDomainGroup, error) {
+	for pageNumber := int64(1); ; pageNumber++ {
+		var describeDomainGroupsResponse *alicloudDnsClient.DescribeDomainGroupsResponse
+		describeDomainGroups := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			describeDomainGroupsRequest := &alicloudDnsClient.DescribeDomainGroupsRequest{
+				PageNumber: tea.Int64(pageNumber),
+				PageSize:   tea.Int64(100),
+			}
+
+			var err error
+			describeDomainGroupsResponse, err = r.client.DescribeDomainGroupsWithOptions(describeDomainGroupsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(describeDomainGroups, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		body := describeDomainGroupsResponse.Body
+		if body.DomainGroups == nil || len(body.DomainGroups.DomainGroup) == 0 {
+			return nil, nil
+		}
+		for _, domainGroup := range body.DomainGroups.DomainGroup {
+			if tea.StringValue(domainGroup.GroupId) == groupId {
+				return domainGroup, nil
+			}
+		}
+		if pageNumber*100 >= tea.Int64Value(body.TotalCount) {
+			return nil, nil
+		}
+	}
+}
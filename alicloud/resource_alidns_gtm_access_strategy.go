@@ -0,0 +1,795 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &aliDnsGtmAccessStrategyResource{}
+	_ resource.ResourceWithConfigure   = &aliDnsGtmAccessStrategyResource{}
+	_ resource.ResourceWithImportState = &aliDnsGtmAccessStrategyResource{}
+	_ resource.ResourceWithModifyPlan  = &aliDnsGtmAccessStrategyResource{}
+)
+
+func NewAliDnsGtmAccessStrategyResource() resource.Resource {
+	return &aliDnsGtmAccessStrategyResource{}
+}
+
+type aliDnsGtmAccessStrategyResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsGtmAccessStrategyResourceModel struct {
+	InstanceId          types.String       `tfsdk:"instance_id"`
+	StrategyId          types.String       `tfsdk:"strategy_id"`
+	StrategyName        types.String       `tfsdk:"strategy_name"`
+	StrategyMode        types.String       `tfsdk:"strategy_mode"`
+	Lines               types.List         `tfsdk:"lines"`
+	DefaultAddressPool  *gtmAddressPoolSet `tfsdk:"default_address_pool"`
+	FailoverAddressPool *gtmAddressPoolSet `tfsdk:"failover_address_pool"`
+	AccessMode          types.String       `tfsdk:"access_mode"`
+}
+
+type gtmAddressPoolSet struct {
+	Type                     types.String            `tfsdk:"type"`
+	AddressPools             []*gtmAddressPoolWeight `tfsdk:"address_pools"`
+	LbaStrategy              types.String            `tfsdk:"lba_strategy"`
+	MinAvailableAddressCount types.Int64             `tfsdk:"min_available_address_count"`
+	MaxReturnAddressCount    types.Int64             `tfsdk:"max_return_address_count"`
+	LatencyOptimization      types.Bool              `tfsdk:"latency_optimization"`
+}
+
+type gtmAddressPoolWeight struct {
+	AddressPoolId types.String `tfsdk:"address_pool_id"`
+	Weight        types.Int64  `tfsdk:"weight"`
+}
+
+// gtmAddressPoolSetRequest is the request parameters of an address pool set,
+// shared by the requests to add and update the access strategy.
+type gtmAddressPoolSetRequest struct {
+	poolIds                  []*string
+	poolWeights              []*int32
+	poolType                 *string
+	lbaStrategy              *string
+	minAvailableAddressCount *int32
+	maxReturnAddressCount    *int32
+	latencyOptimization      *string
+}
+
+// Metadata returns the resource GTM access strategy type name.
+func (r *aliDnsGtmAccessStrategyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_gtm_access_strategy"
+}
+
+// Schema defines the schema for the GTM access strategy resource.
+func (r *aliDnsGtmAccessStrategyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	addressPoolSetAttributes := func(description string, required bool) schema.SingleNestedAttribute {
+		return schema.SingleNestedAttribute{
+			Description: description,
+			Required:    required,
+			Optional:    !required,
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					Description: "The type of the address pools. Valid values: IPV4, IPV6, DOMAIN.",
+					Required:    true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("IPV4", "IPV6", "DOMAIN"),
+					},
+				},
+				"address_pools": schema.ListNestedAttribute{
+					Description: "The address pools of the set.",
+					Required:    true,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+					},
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"address_pool_id": schema.StringAttribute{
+								Description: "The ID of the address pool.",
+								Required:    true,
+							},
+							"weight": schema.Int64Attribute{
+								Description: "The weight of the address pool when lba_strategy is RATIO.",
+								Optional:    true,
+								Validators: []validator.Int64{
+									int64validator.Between(1, 100),
+								},
+							},
+						},
+					},
+				},
+				"lba_strategy": schema.StringAttribute{
+					Description: "The load balancing policy of the address pools in GEO mode. Valid values: " +
+						"ALL_RR to return all the addresses, RATIO to return the addresses by weight.",
+					Optional: true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("ALL_RR", "RATIO"),
+					},
+				},
+				"min_available_address_count": schema.Int64Attribute{
+					Description: "The minimum number of available addresses of the set, below which the set is " +
+						"unavailable.",
+					Required: true,
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+				"max_return_address_count": schema.Int64Attribute{
+					Description: "The maximum number of addresses returned in LATENCY mode.",
+					Optional:    true,
+					Validators: []validator.Int64{
+						int64validator.AtLeast(1),
+					},
+				},
+				"latency_optimization": schema.BoolAttribute{
+					Description: "Whether to return the addresses with the lowest latency in LATENCY mode.",
+					Optional:    true,
+				},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alidns GTM access strategy resource.",
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.StringAttribute{
+				Description: "The ID of Global Traffic Manager instance.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"strategy_id": schema.StringAttribute{
+				Description: "The ID of the access strategy.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"strategy_name": schema.StringAttribute{
+				Description: "The name of the access strategy.",
+				Required:    true,
+			},
+			"strategy_mode": schema.StringAttribute{
+				Description: "The type of the access strategy, which must match the strategy_mode of the " +
+					"instance. Valid values: GEO, LATENCY. Default to the strategy_mode of the instance.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("GEO", "LATENCY"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"lines": schema.ListAttribute{
+				Description: "The source lines of the access strategy, which are required in GEO mode and " +
+					"not supported in LATENCY mode.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"default_address_pool": addressPoolSetAttributes("The default address pool set.", true),
+			"failover_address_pool": addressPoolSetAttributes(
+				"The failover address pool set, used when the default set is unavailable.", false),
+			"access_mode": schema.StringAttribute{
+				Description: "The access mode of the strategy. Valid values: AUTO to switch to the failover " +
+					"set automatically, DEFAULT to always use the default set, FAILOVER to always use the " +
+					"failover set. Default to AUTO.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("AUTO"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("AUTO", "DEFAULT", "FAILOVER"),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aliDnsGtmAccessStrategyResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+// Create a new GTM access strategy resource
+func (r *aliDnsGtmAccessStrategyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan *aliDnsGtmAccessStrategyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lines, err := getGtmAccessStrategyLines(ctx, plan.Lines)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid Lines",
+			err.Error(),
+		)
+		return
+	}
+
+	// The strategy mode is unknown at plan time if the instance is created in
+	// the same apply.
+	if plan.StrategyMode.IsUnknown() || plan.StrategyMode.IsNull() {
+		strategyMode, err := r.describeInstanceStrategyMode(plan.InstanceId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Describe GTM Instance",
+				err.Error(),
+			)
+			return
+		}
+		plan.StrategyMode = types.StringValue(strategyMode)
+	}
+
+	addDnsGtmAccessStrategyRequest := &alicloudDnsClient.AddDnsGtmAccessStrategyRequest{
+		InstanceId:   tea.String(plan.InstanceId.ValueString()),
+		StrategyName: tea.String(plan.StrategyName.ValueString()),
+		StrategyMode: tea.String(plan.StrategyMode.ValueString()),
+		Lines:        lines,
+	}
+	defaultPoolSet := getGtmAddressPoolSetRequest(plan.DefaultAddressPool)
+	addDnsGtmAccessStrategyRequest.DefaultAddrPoolType = defaultPoolSet.poolType
+	addDnsGtmAccessStrategyRequest.DefaultLbaStrategy = defaultPoolSet.lbaStrategy
+	addDnsGtmAccessStrategyRequest.DefaultMinAvailableAddrNum = defaultPoolSet.minAvailableAddressCount
+	addDnsGtmAccessStrategyRequest.DefaultMaxReturnAddrNum = defaultPoolSet.maxReturnAddressCount
+	addDnsGtmAccessStrategyRequest.DefaultLatencyOptimization = defaultPoolSet.latencyOptimization
+	for i := range defaultPoolSet.poolIds {
+		addDnsGtmAccessStrategyRequest.DefaultAddrPool = append(addDnsGtmAccessStrategyRequest.DefaultAddrPool, &alicloudDnsClient.AddDnsGtmAccessStrategyRequestDefaultAddrPool{
+			Id:        defaultPoolSet.poolIds[i],
+			LbaWeight: defaultPoolSet.poolWeights[i],
+		})
+	}
+	if plan.FailoverAddressPool != nil {
+		failoverPoolSet := getGtmAddressPoolSetRequest(plan.FailoverAddressPool)
+		addDnsGtmAccessStrategyRequest.FailoverAddrPoolType = failoverPoolSet.poolType
+		addDnsGtmAccessStrategyRequest.FailoverLbaStrategy = failoverPoolSet.lbaStrategy
+		addDnsGtmAccessStrategyRequest.FailoverMinAvailableAddrNum = failoverPoolSet.minAvailableAddressCount
+		addDnsGtmAccessStrategyRequest.FailoverMaxReturnAddrNum = failoverPoolSet.maxReturnAddressCount
+		addDnsGtmAccessStrategyRequest.FailoverLatencyOptimization = failoverPoolSet.latencyOptimization
+		for i := range failoverPoolSet.poolIds {
+			addDnsGtmAccessStrategyRequest.FailoverAddrPool = append(addDnsGtmAccessStrategyRequest.FailoverAddrPool, &alicloudDnsClient.AddDnsGtmAccessStrategyRequestFailoverAddrPool{
+				Id:        failoverPoolSet.poolIds[i],
+				LbaWeight: failoverPoolSet.poolWeights[i],
+			})
+		}
+	}
+
+	var addDnsGtmAccessStrategyResponse *alicloudDnsClient.AddDnsGtmAccessStrategyResponse
+	addDnsGtmAccessStrategy := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		var err error
+		addDnsGtmAccessStrategyResponse, err = r.client.AddDnsGtmAccessStrategyWithOptions(addDnsGtmAccessStrategyRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(addDnsGtmAccessStrategy, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add GTM Access Strategy",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.StrategyId = types.StringValue(tea.StringValue(addDnsGtmAccessStrategyResponse.Body.StrategyId))
+
+	// Save the strategy before setting the access mode, so that it is not
+	// lost if the access mode fails to be set.
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AccessMode.ValueString() != "AUTO" {
+		if err := r.setAccessMode(plan.StrategyId.ValueString(), plan.AccessMode.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set GTM Access Mode",
+				err.Error(),
+			)
+			return
+		}
+	}
+}
+
+// Read GTM access strategy resource information
+func (r *aliDnsGtmAccessStrategyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state *aliDnsGtmAccessStrategyResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var describeDnsGtmAccessStrategyResponse *alicloudDnsClient.DescribeDnsGtmAccessStrategyResponse
+	describeDnsGtmAccessStrategy := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsGtmAccessStrategyRequest := &alicloudDnsClient.DescribeDnsGtmAccessStrategyRequest{
+			StrategyId: tea.String(state.StrategyId.ValueString()),
+		}
+
+		var err error
+		describeDnsGtmAccessStrategyResponse, err = r.client.DescribeDnsGtmAccessStrategyWithOptions(describeDnsGtmAccessStrategyRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDnsGtmAccessStrategy, reconnectBackoff); err != nil {
+		if isAliDnsGtmNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Access Strategy",
+			err.Error(),
+		)
+		return
+	}
+
+	strategy := describeDnsGtmAccessStrategyResponse.Body
+	state.InstanceId = types.StringValue(tea.StringValue(strategy.InstanceId))
+	state.StrategyName = types.StringValue(tea.StringValue(strategy.StrategyName))
+	state.StrategyMode = types.StringValue(tea.StringValue(strategy.StrategyMode))
+	state.AccessMode = types.StringValue(tea.StringValue(strategy.AccessMode))
+
+	lines := make([]attr.Value, 0)
+	if strategy.Lines != nil {
+		for _, line := range strategy.Lines.Line {
+			lines = append(lines, types.StringValue(tea.StringValue(line.LineCode)))
+		}
+	}
+	if len(lines) > 0 || !state.Lines.IsNull() {
+		state.Lines = types.ListValueMust(types.StringType, lines)
+	}
+
+	defaultPools := make([]*gtmAddressPoolWeight, 0)
+	if strategy.DefaultAddrPools != nil {
+		for _, pool := range strategy.DefaultAddrPools.DefaultAddrPool {
+			defaultPools = append(defaultPools, &gtmAddressPoolWeight{
+				AddressPoolId: types.StringValue(tea.StringValue(pool.Id)),
+				Weight:        types.Int64Value(int64(tea.Int32Value(pool.LbaWeight))),
+			})
+		}
+	}
+	state.DefaultAddressPool = readGtmAddressPoolSet(state.DefaultAddressPool, defaultPools,
+		strategy.DefaultAddrPoolType, strategy.DefaultLbaStrategy, strategy.DefaultMinAvailableAddrNum,
+		strategy.DefaultMaxReturnAddrNum, strategy.DefaultLatencyOptimization)
+
+	failoverPools := make([]*gtmAddressPoolWeight, 0)
+	if strategy.FailoverAddrPools != nil {
+		for _, pool := range strategy.FailoverAddrPools.FailoverAddrPool {
+			failoverPools = append(failoverPools, &gtmAddressPoolWeight{
+				AddressPoolId: types.StringValue(tea.StringValue(pool.Id)),
+				Weight:        types.Int64Value(int64(tea.Int32Value(pool.LbaWeight))),
+			})
+		}
+	}
+	if len(failoverPools) > 0 {
+		state.FailoverAddressPool = readGtmAddressPoolSet(state.FailoverAddressPool, failoverPools,
+			strategy.FailoverAddrPoolType, strategy.FailoverLbaStrategy, strategy.FailoverMinAvailableAddrNum,
+			strategy.FailoverMaxReturnAddrNum, strategy.FailoverLatencyOptimization)
+	} else {
+		state.FailoverAddressPool = nil
+	}
+
+	// Set refreshed state
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the GTM access strategy resource and sets the updated Terraform state on success.
+func (r *aliDnsGtmAccessStrategyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *aliDnsGtmAccessStrategyResourceModel
+
+	// Retrieve values from plan
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lines, err := getGtmAccessStrategyLines(ctx, plan.Lines)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid Lines",
+			err.Error(),
+		)
+		return
+	}
+
+	updateDnsGtmAccessStrategyRequest := &alicloudDnsClient.UpdateDnsGtmAccessStrategyRequest{
+		StrategyId:   tea.String(state.StrategyId.ValueString()),
+		StrategyName: tea.String(plan.StrategyName.ValueString()),
+		Lines:        lines,
+		AccessMode:   tea.String(plan.AccessMode.ValueString()),
+	}
+	defaultPoolSet := getGtmAddressPoolSetRequest(plan.DefaultAddressPool)
+	updateDnsGtmAccessStrategyRequest.DefaultAddrPoolType = defaultPoolSet.poolType
+	updateDnsGtmAccessStrategyRequest.DefaultLbaStrategy = defaultPoolSet.lbaStrategy
+	updateDnsGtmAccessStrategyRequest.DefaultMinAvailableAddrNum = defaultPoolSet.minAvailableAddressCount
+	updateDnsGtmAccessStrategyRequest.DefaultMaxReturnAddrNum = defaultPoolSet.maxReturnAddressCount
+	updateDnsGtmAccessStrategyRequest.DefaultLatencyOptimization = defaultPoolSet.latencyOptimization
+	for i := range defaultPoolSet.poolIds {
+		updateDnsGtmAccessStrategyRequest.DefaultAddrPool = append(updateDnsGtmAccessStrategyRequest.DefaultAddrPool, &alicloudDnsClient.UpdateDnsGtmAccessStrategyRequestDefaultAddrPool{
+			Id:        defaultPoolSet.poolIds[i],
+			LbaWeight: defaultPoolSet.poolWeights[i],
+		})
+	}
+	if plan.FailoverAddressPool != nil {
+		failoverPoolSet := getGtmAddressPoolSetRequest(plan.FailoverAddressPool)
+		updateDnsGtmAccessStrategyRequest.FailoverAddrPoolType = failoverPoolSet.poolType
+		updateDnsGtmAccessStrategyRequest.FailoverLbaStrategy = failoverPoolSet.lbaStrategy
+		updateDnsGtmAccessStrategyRequest.FailoverMinAvailableAddrNum = failoverPoolSet.minAvailableAddressCount
+		updateDnsGtmAccessStrategyRequest.FailoverMaxReturnAddrNum = failoverPoolSet.maxReturnAddressCount
+		updateDnsGtmAccessStrategyRequest.FailoverLatencyOptimization = failoverPoolSet.latencyOptimization
+		for i := range failoverPoolSet.poolIds {
+			updateDnsGtmAccessStrategyRequest.FailoverAddrPool = append(updateDnsGtmAccessStrategyRequest.FailoverAddrPool, &alicloudDnsClient.UpdateDnsGtmAccessStrategyRequestFailoverAddrPool{
+				Id:        failoverPoolSet.poolIds[i],
+				LbaWeight: failoverPoolSet.poolWeights[i],
+			})
+		}
+	}
+
+	updateDnsGtmAccessStrategy := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		if _, err := r.client.UpdateDnsGtmAccessStrategyWithOptions(updateDnsGtmAccessStrategyRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(updateDnsGtmAccessStrategy, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update GTM Access Strategy",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.StrategyId = state.StrategyId
+
+	// Set state to plan data
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the GTM access strategy resource and removes the Terraform state on success.
+func (r *aliDnsGtmAccessStrategyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state *aliDnsGtmAccessStrategyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteDnsGtmAccessStrategy := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteDnsGtmAccessStrategyRequest := &alicloudDnsClient.DeleteDnsGtmAccessStrategyRequest{
+			StrategyId: tea.String(state.StrategyId.ValueString()),
+		}
+		if _, err := r.client.DeleteDnsGtmAccessStrategyWithOptions(deleteDnsGtmAccessStrategyRequest, runtime); err != nil {
+			if isAliDnsGtmNotFoundError(err) {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteDnsGtmAccessStrategy, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete GTM Access Strategy",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *aliDnsGtmAccessStrategyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import strategy ID and save to strategy_id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("strategy_id"), req, resp)
+}
+
+// ModifyPlan validates the access strategy against the strategy mode of the
+// instance: lines are required in GEO mode, and the latency settings are only
+// supported in LATENCY mode.
+func (r *aliDnsGtmAccessStrategyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *aliDnsGtmAccessStrategyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.AccessMode.ValueString() == "FAILOVER" && plan.FailoverAddressPool == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("access_mode"),
+			"[ERROR] Missing Failover Address Pool",
+			"access_mode FAILOVER requires failover_address_pool.",
+		)
+	}
+
+	if plan.InstanceId.IsUnknown() || r.client == nil {
+		return
+	}
+
+	strategyMode, err := r.describeInstanceStrategyMode(plan.InstanceId.ValueString())
+	if err != nil {
+		// The instance may be created in the same apply.
+		if isAliDnsGtmNotFoundError(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Instance",
+			err.Error(),
+		)
+		return
+	}
+	if strategyMode == "" {
+		return
+	}
+
+	if plan.StrategyMode.IsUnknown() || plan.StrategyMode.IsNull() {
+		plan.StrategyMode = types.StringValue(strategyMode)
+	} else if plan.StrategyMode.ValueString() != strategyMode {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("strategy_mode"),
+			"[ERROR] Invalid Strategy Mode",
+			fmt.Sprintf("strategy_mode must match the strategy mode %s of the instance %s.",
+				strategyMode, plan.InstanceId.ValueString()),
+		)
+		return
+	}
+
+	switch strategyMode {
+	case "GEO":
+		if plan.Lines.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("lines"),
+				"[ERROR] Missing Lines",
+				fmt.Sprintf("lines are required as the instance %s is in GEO mode.", plan.InstanceId.ValueString()),
+			)
+		}
+		for name, poolSet := range map[string]*gtmAddressPoolSet{
+			"default_address_pool":  plan.DefaultAddressPool,
+			"failover_address_pool": plan.FailoverAddressPool,
+		} {
+			if poolSet != nil && (!poolSet.LatencyOptimization.IsNull() || !poolSet.MaxReturnAddressCount.IsNull()) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"[ERROR] Invalid Latency Settings",
+					fmt.Sprintf("latency_optimization and max_return_address_count are not supported as the "+
+						"instance %s is in GEO mode.", plan.InstanceId.ValueString()),
+				)
+			}
+		}
+	case "LATENCY":
+		if !plan.Lines.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("lines"),
+				"[ERROR] Invalid Lines",
+				fmt.Sprintf("lines are not supported as the instance %s is in LATENCY mode.", plan.InstanceId.ValueString()),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setPlanDiags := resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(setPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// describeInstanceStrategyMode returns the strategy mode of the GTM instance.
+func (r *aliDnsGtmAccessStrategyResource) describeInstanceStrategyMode(instanceId string) (string, error) {
+	var describeDnsGtmInstanceResponse *alicloudDnsClient.DescribeDnsGtmInstanceResponse
+	describeDnsGtmInstance := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsGtmInstanceRequest := &alicloudDnsClient.DescribeDnsGtmInstanceRequest{
+			InstanceId: tea.String(instanceId),
+		}
+
+		var err error
+		describeDnsGtmInstanceResponse, err = r.client.DescribeDnsGtmInstanceWithOptions(describeDnsGtmInstanceRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDnsGtmInstance, reconnectBackoff); err != nil {
+		return "", err
+	}
+
+	if config := describeDnsGtmInstanceResponse.Body.Config; config != nil {
+		return tea.StringValue(config.StrategyMode), nil
+	}
+	return "", nil
+}
+
+func (r *aliDnsGtmAccessStrategyResource) setAccessMode(strategyId, accessMode string) error {
+	setDnsGtmAccessMode := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		setDnsGtmAccessModeRequest := &alicloudDnsClient.SetDnsGtmAccessModeRequest{
+			StrategyId: tea.String(strategyId),
+			AccessMode: tea.String(accessMode),
+		}
+		if _, err := r.client.SetDnsGtmAccessModeWithOptions(setDnsGtmAccessModeRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setDnsGtmAccessMode, reconnectBackoff)
+}
+
+// getGtmAccessStrategyLines converts the lines into the JSON array of the
+// request.
+func getGtmAccessStrategyLines(ctx context.Context, lines types.List) (*string, error) {
+	if lines.IsNull() {
+		return nil, nil
+	}
+
+	lineCodes := make([]string, 0)
+	if diags := lines.ElementsAs(ctx, &lineCodes, false); diags.HasError() {
+		return nil, fmt.Errorf("invalid lines")
+	}
+	linesJson, err := json.Marshal(lineCodes)
+	if err != nil {
+		return nil, err
+	}
+	return tea.String(string(linesJson)), nil
+}
+
+func getGtmAddressPoolSetRequest(poolSet *gtmAddressPoolSet) *gtmAddressPoolSetRequest {
+	request := &gtmAddressPoolSetRequest{
+		poolType:                 tea.String(poolSet.Type.ValueString()),
+		minAvailableAddressCount: tea.Int32(int32(poolSet.MinAvailableAddressCount.ValueInt64())),
+	}
+	for _, pool := range poolSet.AddressPools {
+		request.poolIds = append(request.poolIds, tea.String(pool.AddressPoolId.ValueString()))
+		if pool.Weight.IsNull() {
+			request.poolWeights = append(request.poolWeights, nil)
+		} else {
+			request.poolWeights = append(request.poolWeights, tea.Int32(int32(pool.Weight.ValueInt64())))
+		}
+	}
+	if !poolSet.LbaStrategy.IsNull() {
+		request.lbaStrategy = tea.String(poolSet.LbaStrategy.ValueString())
+	}
+	if !poolSet.MaxReturnAddressCount.IsNull() {
+		request.maxReturnAddressCount = tea.Int32(int32(poolSet.MaxReturnAddressCount.ValueInt64()))
+	}
+	if !poolSet.LatencyOptimization.IsNull() {
+		request.latencyOptimization = tea.String(getGtmMonitorStatus(poolSet.LatencyOptimization.ValueBool()))
+	}
+	return request
+}
+
+// readGtmAddressPoolSet converts the address pool set of the strategy into
+// the attribute, keeping the omitted attributes null unless they are set.
+func readGtmAddressPoolSet(statePoolSet *gtmAddressPoolSet, pools []*gtmAddressPoolWeight, poolType, lbaStrategy *string, minAvailableAddressCount, maxReturnAddressCount *int32, latencyOptimization *string) *gtmAddressPoolSet {
+	if statePoolSet == nil {
+		statePoolSet = &gtmAddressPoolSet{
+			LbaStrategy:           types.StringNull(),
+			MaxReturnAddressCount: types.Int64Null(),
+			LatencyOptimization:   types.BoolNull(),
+		}
+	}
+
+	stateWeights := make(map[string]types.Int64)
+	for _, pool := range statePoolSet.AddressPools {
+		stateWeights[pool.AddressPoolId.ValueString()] = pool.Weight
+	}
+	// The API returns a weight for every pool, even 0 with ALL_RR, so the
+	// weights are only kept if they are configured or used by RATIO.
+	for _, pool := range pools {
+		weight, ok := stateWeights[pool.AddressPoolId.ValueString()]
+		if (!ok || weight.IsNull()) && tea.StringValue(lbaStrategy) != "RATIO" {
+			pool.Weight = types.Int64Null()
+		}
+	}
+
+	poolSet := &gtmAddressPoolSet{
+		Type:                     types.StringValue(tea.StringValue(poolType)),
+		AddressPools:             pools,
+		MinAvailableAddressCount: types.Int64Value(int64(tea.Int32Value(minAvailableAddressCount))),
+		LbaStrategy:              statePoolSet.LbaStrategy,
+		MaxReturnAddressCount:    statePoolSet.MaxReturnAddressCount,
+		LatencyOptimization:      statePoolSet.LatencyOptimization,
+	}
+	// The API returns ALL_RR by default even if lba_strategy is omitted, so
+	// it is only read if it is configured, or if it is RATIO.
+	if !statePoolSet.LbaStrategy.IsNull() || tea.StringValue(lbaStrategy) == "RATIO" {
+		poolSet.LbaStrategy = types.StringValue(tea.StringValue(lbaStrategy))
+	}
+	if !statePoolSet.MaxReturnAddressCount.IsNull() {
+		poolSet.MaxReturnAddressCount = types.Int64Value(int64(tea.Int32Value(maxReturnAddressCount)))
+	}
+	if !statePoolSet.LatencyOptimization.IsNull() || tea.StringValue(latencyOptimization) == "OPEN" {
+		poolSet.LatencyOptimization = types.BoolValue(tea.StringValue(latencyOptimization) == "OPEN")
+	}
+	return poolSet
+}
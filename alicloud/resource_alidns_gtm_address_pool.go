@@ -0,0 +1,813 @@
+package alicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &aliDnsGtmAddressPoolResource{}
+	_ resource.ResourceWithConfigure   = &aliDnsGtmAddressPoolResource{}
+	_ resource.ResourceWithImportState = &aliDnsGtmAddressPoolResource{}
+)
+
+func NewAliDnsGtmAddressPoolResource() resource.Resource {
+	return &aliDnsGtmAddressPoolResource{}
+}
+
+type aliDnsGtmAddressPoolResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsGtmAddressPoolResourceModel struct {
+	InstanceId      types.String      `tfsdk:"instance_id"`
+	AddressPoolId   types.String      `tfsdk:"address_pool_id"`
+	Name            types.String      `tfsdk:"name"`
+	Type            types.String      `tfsdk:"type"`
+	LbaStrategy     types.String      `tfsdk:"lba_strategy"`
+	Addresses       []*gtmAddress     `tfsdk:"addresses"`
+	Monitor         *gtmMonitorConfig `tfsdk:"monitor"`
+	MonitorConfigId types.String      `tfsdk:"monitor_config_id"`
+}
+
+type gtmAddress struct {
+	Address types.String `tfsdk:"address"`
+	Lines   types.List   `tfsdk:"lines"`
+	Weight  types.Int64  `tfsdk:"weight"`
+	Mode    types.String `tfsdk:"mode"`
+	Remark  types.String `tfsdk:"remark"`
+}
+
+type gtmMonitorConfig struct {
+	Protocol        types.String      `tfsdk:"protocol"`
+	Interval        types.Int64       `tfsdk:"interval"`
+	EvaluationCount types.Int64       `tfsdk:"evaluation_count"`
+	Timeout         types.Int64       `tfsdk:"timeout"`
+	ExtendInfo      types.String      `tfsdk:"extend_info"`
+	IspCityNodes    []*gtmIspCityNode `tfsdk:"isp_city_nodes"`
+	Enabled         types.Bool        `tfsdk:"enabled"`
+}
+
+type gtmIspCityNode struct {
+	IspCode  types.String `tfsdk:"isp_code"`
+	CityCode types.String `tfsdk:"city_code"`
+}
+
+// gtmAddressAttributeInfo is the JSON of the source lines of an address.
+type gtmAddressAttributeInfo struct {
+	LineCodes           []string `json:"lineCodes"`
+	LineCodeRectifyType string   `json:"lineCodeRectifyType"`
+}
+
+// Metadata returns the resource GTM address pool type name.
+func (r *aliDnsGtmAddressPoolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_gtm_address_pool"
+}
+
+// Schema defines the schema for the GTM address pool resource.
+func (r *aliDnsGtmAddressPoolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alidns GTM address pool resource, together with the health check of the pool. " +
+			"The minimum number of available addresses is configured on st-alicloud_alidns_gtm_access_strategy " +
+			"(min_available_address_count), as the GTM API only accepts it for the address pools of an access " +
+			"strategy, which may differ between the strategies using the same pool.",
+		Attributes: map[string]schema.Attribute{
+			"instance_id": schema.StringAttribute{
+				Description: "The ID of Global Traffic Manager instance.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"address_pool_id": schema.StringAttribute{
+				Description: "The ID of the address pool.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the address pool.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The type of the address pool. Valid values: IPV4, IPV6, DOMAIN.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("IPV4", "IPV6", "DOMAIN"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"lba_strategy": schema.StringAttribute{
+				Description: "The load balancing policy of the address pool. Valid values: ALL_RR to return all " +
+					"the addresses, RATIO to return the addresses by weight. Default to ALL_RR.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("ALL_RR"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("ALL_RR", "RATIO"),
+				},
+			},
+			"addresses": schema.ListNestedAttribute{
+				Description: "The addresses of the address pool.",
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Description: "The IP address or domain name.",
+							Required:    true,
+						},
+						"lines": schema.ListAttribute{
+							Description: "The source lines of the address, such as default, or the line codes " +
+								"of regions and carriers.",
+							Required:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+						"weight": schema.Int64Attribute{
+							Description: "The weight of the address when lba_strategy is RATIO.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 100),
+							},
+						},
+						"mode": schema.StringAttribute{
+							Description: "The mode of the address. Valid values: SMART to follow the health check, " +
+								"ONLINE to always be available, OFFLINE to always be unavailable. Default to SMART.",
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString("SMART"),
+							Validators: []validator.String{
+								stringvalidator.OneOf("SMART", "ONLINE", "OFFLINE"),
+							},
+						},
+						"remark": schema.StringAttribute{
+							Description: "The remark of the address.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"monitor": schema.SingleNestedAttribute{
+				Description: "The health check of the address pool.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"protocol": schema.StringAttribute{
+						Description: "The protocol of the health check. Valid values: HTTP, HTTPS, PING, TCP.",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("HTTP", "HTTPS", "PING", "TCP"),
+						},
+					},
+					"interval": schema.Int64Attribute{
+						Description: "The interval of the health check in seconds. Default to 60.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(60),
+					},
+					"evaluation_count": schema.Int64Attribute{
+						Description: "The number of consecutive failures before an address is unavailable. " +
+							"Valid values: 1, 2, 3, 5. Default to 1.",
+						Optional: true,
+						Computed: true,
+						Default:  int64default.StaticInt64(1),
+						Validators: []validator.Int64{
+							int64validator.OneOf(1, 2, 3, 5),
+						},
+					},
+					"timeout": schema.Int64Attribute{
+						Description: "The timeout of the health check in milliseconds. Default to 5000.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(5000),
+					},
+					"extend_info": schema.StringAttribute{
+						Description: "The JSON of the extended settings of the protocol, such as " +
+							`{"host":"example.com","path":"/health","code":400} for HTTP, or ` +
+							`{"packetNum":20,"packetLossRate":10} for PING.`,
+						Required: true,
+					},
+					"isp_city_nodes": schema.ListNestedAttribute{
+						Description: "The monitor nodes of the health check.",
+						Required:    true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"isp_code": schema.StringAttribute{
+									Description: "The code of the carrier.",
+									Required:    true,
+								},
+								"city_code": schema.StringAttribute{
+									Description: "The code of the city.",
+									Required:    true,
+								},
+							},
+						},
+					},
+					"enabled": schema.BoolAttribute{
+						Description: "Whether the health check is enabled. Default to true.",
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(true),
+					},
+				},
+			},
+			"monitor_config_id": schema.StringAttribute{
+				Description: "The ID of the health check of the address pool.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aliDnsGtmAddressPoolResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+// Create a new GTM address pool resource
+func (r *aliDnsGtmAddressPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan *aliDnsGtmAddressPoolResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addrs, err := getGtmAddressPoolAddrs(ctx, plan.Addresses)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid Addresses",
+			err.Error(),
+		)
+		return
+	}
+
+	addDnsGtmAddressPoolRequest := &alicloudDnsClient.AddDnsGtmAddressPoolRequest{
+		InstanceId:  tea.String(plan.InstanceId.ValueString()),
+		Name:        tea.String(plan.Name.ValueString()),
+		Type:        tea.String(plan.Type.ValueString()),
+		LbaStrategy: tea.String(plan.LbaStrategy.ValueString()),
+	}
+	for _, addr := range addrs {
+		addDnsGtmAddressPoolRequest.Addr = append(addDnsGtmAddressPoolRequest.Addr, &alicloudDnsClient.AddDnsGtmAddressPoolRequestAddr{
+			Addr:          addr.Addr,
+			AttributeInfo: addr.AttributeInfo,
+			LbaWeight:     addr.LbaWeight,
+			Mode:          addr.Mode,
+			Remark:        addr.Remark,
+		})
+	}
+	if plan.Monitor != nil {
+		addDnsGtmAddressPoolRequest.MonitorStatus = tea.String(getGtmMonitorStatus(plan.Monitor.Enabled.ValueBool()))
+		addDnsGtmAddressPoolRequest.ProtocolType = tea.String(plan.Monitor.Protocol.ValueString())
+		addDnsGtmAddressPoolRequest.Interval = tea.Int32(int32(plan.Monitor.Interval.ValueInt64()))
+		addDnsGtmAddressPoolRequest.EvaluationCount = tea.Int32(int32(plan.Monitor.EvaluationCount.ValueInt64()))
+		addDnsGtmAddressPoolRequest.Timeout = tea.Int32(int32(plan.Monitor.Timeout.ValueInt64()))
+		addDnsGtmAddressPoolRequest.MonitorExtendInfo = tea.String(plan.Monitor.ExtendInfo.ValueString())
+		for _, node := range plan.Monitor.IspCityNodes {
+			addDnsGtmAddressPoolRequest.IspCityNode = append(addDnsGtmAddressPoolRequest.IspCityNode, &alicloudDnsClient.AddDnsGtmAddressPoolRequestIspCityNode{
+				IspCode:  tea.String(node.IspCode.ValueString()),
+				CityCode: tea.String(node.CityCode.ValueString()),
+			})
+		}
+	}
+
+	var addDnsGtmAddressPoolResponse *alicloudDnsClient.AddDnsGtmAddressPoolResponse
+	addDnsGtmAddressPool := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		var err error
+		addDnsGtmAddressPoolResponse, err = r.client.AddDnsGtmAddressPoolWithOptions(addDnsGtmAddressPoolRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(addDnsGtmAddressPool, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.AddressPoolId = types.StringValue(tea.StringValue(addDnsGtmAddressPoolResponse.Body.AddrPoolId))
+	plan.MonitorConfigId = types.StringValue(tea.StringValue(addDnsGtmAddressPoolResponse.Body.MonitorConfigId))
+
+	// Set state to fully populated data
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read GTM address pool resource information
+func (r *aliDnsGtmAddressPoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state *aliDnsGtmAddressPoolResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var describeDnsGtmInstanceAddressPoolResponse *alicloudDnsClient.DescribeDnsGtmInstanceAddressPoolResponse
+	describeDnsGtmInstanceAddressPool := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsGtmInstanceAddressPoolRequest := &alicloudDnsClient.DescribeDnsGtmInstanceAddressPoolRequest{
+			AddrPoolId: tea.String(state.AddressPoolId.ValueString()),
+		}
+
+		var err error
+		describeDnsGtmInstanceAddressPoolResponse, err = r.client.DescribeDnsGtmInstanceAddressPoolWithOptions(describeDnsGtmInstanceAddressPoolRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDnsGtmInstanceAddressPool, reconnectBackoff); err != nil {
+		if isAliDnsGtmNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+
+	addressPool := describeDnsGtmInstanceAddressPoolResponse.Body
+	state.Name = types.StringValue(tea.StringValue(addressPool.Name))
+	state.Type = types.StringValue(tea.StringValue(addressPool.Type))
+	state.LbaStrategy = types.StringValue(tea.StringValue(addressPool.LbaStrategy))
+	state.MonitorConfigId = types.StringValue(tea.StringValue(addressPool.MonitorConfigId))
+
+	// Only the ID and the instance ID are set when importing
+	importing := state.Addresses == nil
+
+	// Keep the order of the addresses in state, and append the addresses
+	// added outside Terraform.
+	stateAddresses := make(map[string]*gtmAddress)
+	for _, address := range state.Addresses {
+		stateAddresses[address.Address.ValueString()] = address
+	}
+	remoteAddresses := make(map[string]*gtmAddress)
+	remoteOrder := make([]string, 0)
+	if addressPool.Addrs != nil {
+		for _, addr := range addressPool.Addrs.Addr {
+			address := &gtmAddress{
+				Address: types.StringValue(tea.StringValue(addr.Addr)),
+				Weight:  types.Int64Null(),
+				Mode:    types.StringValue(tea.StringValue(addr.Mode)),
+				Remark:  types.StringNull(),
+			}
+
+			lines := make([]attr.Value, 0)
+			attributeInfo := &gtmAddressAttributeInfo{}
+			if err := json.Unmarshal([]byte(tea.StringValue(addr.AttributeInfo)), attributeInfo); err == nil {
+				for _, line := range attributeInfo.LineCodes {
+					lines = append(lines, types.StringValue(line))
+				}
+			}
+			address.Lines = types.ListValueMust(types.StringType, lines)
+
+			stateAddress := stateAddresses[address.Address.ValueString()]
+			if (stateAddress != nil && !stateAddress.Weight.IsNull()) || state.LbaStrategy.ValueString() == "RATIO" {
+				address.Weight = types.Int64Value(int64(tea.Int32Value(addr.LbaWeight)))
+			}
+			if (stateAddress != nil && !stateAddress.Remark.IsNull()) || tea.StringValue(addr.Remark) != "" {
+				address.Remark = types.StringValue(tea.StringValue(addr.Remark))
+			}
+
+			remoteAddresses[address.Address.ValueString()] = address
+			remoteOrder = append(remoteOrder, address.Address.ValueString())
+		}
+	}
+	addresses := make([]*gtmAddress, 0, len(remoteAddresses))
+	for _, address := range state.Addresses {
+		if remoteAddress, ok := remoteAddresses[address.Address.ValueString()]; ok {
+			addresses = append(addresses, remoteAddress)
+		}
+	}
+	for _, addr := range remoteOrder {
+		if _, ok := stateAddresses[addr]; !ok {
+			addresses = append(addresses, remoteAddresses[addr])
+		}
+	}
+	state.Addresses = addresses
+
+	// The health check is only read if it is managed, or on import.
+	monitorEnabled := tea.StringValue(addressPool.MonitorStatus) == "OPEN"
+	if state.MonitorConfigId.ValueString() != "" && (state.Monitor != nil || (importing && monitorEnabled)) {
+		monitor, err := r.describeMonitor(state.MonitorConfigId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Describe GTM Monitor Config",
+				err.Error(),
+			)
+			return
+		}
+		if state.Monitor != nil && isJsonEqual(state.Monitor.ExtendInfo.ValueString(), monitor.ExtendInfo.ValueString()) {
+			monitor.ExtendInfo = state.Monitor.ExtendInfo
+		}
+		monitor.Enabled = types.BoolValue(monitorEnabled)
+		state.Monitor = monitor
+	}
+
+	// Set refreshed state
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the GTM address pool resource and sets the updated Terraform state on success.
+func (r *aliDnsGtmAddressPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *aliDnsGtmAddressPoolResourceModel
+
+	// Retrieve values from plan
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	addrs, err := getGtmAddressPoolAddrs(ctx, plan.Addresses)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid Addresses",
+			err.Error(),
+		)
+		return
+	}
+
+	updateDnsGtmAddressPool := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateDnsGtmAddressPoolRequest := &alicloudDnsClient.UpdateDnsGtmAddressPoolRequest{
+			AddrPoolId:  tea.String(state.AddressPoolId.ValueString()),
+			Name:        tea.String(plan.Name.ValueString()),
+			LbaStrategy: tea.String(plan.LbaStrategy.ValueString()),
+			Addr:        addrs,
+		}
+		if _, err := r.client.UpdateDnsGtmAddressPoolWithOptions(updateDnsGtmAddressPoolRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(updateDnsGtmAddressPool, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.AddressPoolId = state.AddressPoolId
+	plan.MonitorConfigId = state.MonitorConfigId
+	if err := r.updateMonitor(plan, state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update GTM Monitor Config",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state to plan data
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the GTM address pool resource and removes the Terraform state on success.
+func (r *aliDnsGtmAddressPoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state *aliDnsGtmAddressPoolResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteDnsGtmAddressPool := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteDnsGtmAddressPoolRequest := &alicloudDnsClient.DeleteDnsGtmAddressPoolRequest{
+			AddrPoolId: tea.String(state.AddressPoolId.ValueString()),
+		}
+		if _, err := r.client.DeleteDnsGtmAddressPoolWithOptions(deleteDnsGtmAddressPoolRequest, runtime); err != nil {
+			if isAliDnsGtmNotFoundError(err) {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteDnsGtmAddressPool, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete GTM Address Pool",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *aliDnsGtmAddressPoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The instance ID is not returned with the address pool, so it is part
+	// of the import ID.
+	instanceId, addressPoolId, found := strings.Cut(req.ID, "/")
+	if !found || instanceId == "" || addressPoolId == "" {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid Import ID",
+			"Expected import ID in the format of <instance_id>/<address_pool_id>, got: "+req.ID,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("instance_id"), instanceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("address_pool_id"), addressPoolId)...)
+}
+
+// updateMonitor creates, updates, enables or disables the health check of the
+// address pool. A removed health check is disabled, as it can not be deleted.
+func (r *aliDnsGtmAddressPoolResource) updateMonitor(plan, state *aliDnsGtmAddressPoolResourceModel) error {
+	if plan.Monitor == nil {
+		if state.Monitor == nil || !state.Monitor.Enabled.ValueBool() {
+			return nil
+		}
+		return r.setMonitorStatus(plan.MonitorConfigId.ValueString(), false)
+	}
+
+	if reflect.DeepEqual(plan.Monitor, state.Monitor) {
+		return nil
+	}
+
+	var ispCityNodes []*alicloudDnsClient.UpdateDnsGtmMonitorRequestIspCityNode
+	for _, node := range plan.Monitor.IspCityNodes {
+		ispCityNodes = append(ispCityNodes, &alicloudDnsClient.UpdateDnsGtmMonitorRequestIspCityNode{
+			IspCode:  tea.String(node.IspCode.ValueString()),
+			CityCode: tea.String(node.CityCode.ValueString()),
+		})
+	}
+
+	if plan.MonitorConfigId.ValueString() == "" {
+		addDnsGtmMonitorRequest := &alicloudDnsClient.AddDnsGtmMonitorRequest{
+			AddrPoolId:        tea.String(plan.AddressPoolId.ValueString()),
+			ProtocolType:      tea.String(plan.Monitor.Protocol.ValueString()),
+			Interval:          tea.Int32(int32(plan.Monitor.Interval.ValueInt64())),
+			EvaluationCount:   tea.Int32(int32(plan.Monitor.EvaluationCount.ValueInt64())),
+			Timeout:           tea.Int32(int32(plan.Monitor.Timeout.ValueInt64())),
+			MonitorExtendInfo: tea.String(plan.Monitor.ExtendInfo.ValueString()),
+		}
+		for _, node := range ispCityNodes {
+			addDnsGtmMonitorRequest.IspCityNode = append(addDnsGtmMonitorRequest.IspCityNode, &alicloudDnsClient.AddDnsGtmMonitorRequestIspCityNode{
+				IspCode:  node.IspCode,
+				CityCode: node.CityCode,
+			})
+		}
+
+		addDnsGtmMonitor := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			addDnsGtmMonitorResponse, err := r.client.AddDnsGtmMonitorWithOptions(addDnsGtmMonitorRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			plan.MonitorConfigId = types.StringValue(tea.StringValue(addDnsGtmMonitorResponse.Body.MonitorConfigId))
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(addDnsGtmMonitor, reconnectBackoff); err != nil {
+			return err
+		}
+	} else {
+		updateDnsGtmMonitor := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			updateDnsGtmMonitorRequest := &alicloudDnsClient.UpdateDnsGtmMonitorRequest{
+				MonitorConfigId:   tea.String(plan.MonitorConfigId.ValueString()),
+				ProtocolType:      tea.String(plan.Monitor.Protocol.ValueString()),
+				Interval:          tea.Int32(int32(plan.Monitor.Interval.ValueInt64())),
+				EvaluationCount:   tea.Int32(int32(plan.Monitor.EvaluationCount.ValueInt64())),
+				Timeout:           tea.Int32(int32(plan.Monitor.Timeout.ValueInt64())),
+				MonitorExtendInfo: tea.String(plan.Monitor.ExtendInfo.ValueString()),
+				IspCityNode:       ispCityNodes,
+			}
+			if _, err := r.client.UpdateDnsGtmMonitorWithOptions(updateDnsGtmMonitorRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(updateDnsGtmMonitor, reconnectBackoff); err != nil {
+			return err
+		}
+	}
+
+	// A newly added health check is disabled.
+	if state.Monitor == nil || plan.Monitor.Enabled.ValueBool() != state.Monitor.Enabled.ValueBool() {
+		return r.setMonitorStatus(plan.MonitorConfigId.ValueString(), plan.Monitor.Enabled.ValueBool())
+	}
+	return nil
+}
+
+func (r *aliDnsGtmAddressPoolResource) setMonitorStatus(monitorConfigId string, enabled bool) error {
+	setDnsGtmMonitorStatus := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		setDnsGtmMonitorStatusRequest := &alicloudDnsClient.SetDnsGtmMonitorStatusRequest{
+			MonitorConfigId: tea.String(monitorConfigId),
+			Status:          tea.String(getGtmMonitorStatus(enabled)),
+		}
+		if _, err := r.client.SetDnsGtmMonitorStatusWithOptions(setDnsGtmMonitorStatusRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setDnsGtmMonitorStatus, reconnectBackoff)
+}
+
+func (r *aliDnsGtmAddressPoolResource) describeMonitor(monitorConfigId string) (*gtmMonitorConfig, error) {
+	var describeDnsGtmMonitorConfigResponse *alicloudDnsClient.DescribeDnsGtmMonitorConfigResponse
+	describeDnsGtmMonitorConfig := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDnsGtmMonitorConfigRequest := &alicloudDnsClient.DescribeDnsGtmMonitorConfigRequest{
+			MonitorConfigId: tea.String(monitorConfigId),
+		}
+
+		var err error
+		describeDnsGtmMonitorConfigResponse, err = r.client.DescribeDnsGtmMonitorConfigWithOptions(describeDnsGtmMonitorConfigRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDnsGtmMonitorConfig, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	monitorConfig := describeDnsGtmMonitorConfigResponse.Body
+	monitor := &gtmMonitorConfig{
+		Protocol:        types.StringValue(tea.StringValue(monitorConfig.ProtocolType)),
+		Interval:        types.Int64Value(int64(tea.Int32Value(monitorConfig.Interval))),
+		EvaluationCount: types.Int64Value(int64(tea.Int32Value(monitorConfig.EvaluationCount))),
+		Timeout:         types.Int64Value(int64(tea.Int32Value(monitorConfig.Timeout))),
+		ExtendInfo:      types.StringValue(tea.StringValue(monitorConfig.MonitorExtendInfo)),
+		IspCityNodes:    []*gtmIspCityNode{},
+	}
+	if monitorConfig.IspCityNodes != nil {
+		for _, node := range monitorConfig.IspCityNodes.IspCityNode {
+			monitor.IspCityNodes = append(monitor.IspCityNodes, &gtmIspCityNode{
+				IspCode:  types.StringValue(tea.StringValue(node.IspCode)),
+				CityCode: types.StringValue(tea.StringValue(node.CityCode)),
+			})
+		}
+	}
+	return monitor, nil
+}
+
+// getGtmAddressPoolAddrs converts the addresses into the addresses of the
+// request, with the source lines as the attribute info JSON.
+func getGtmAddressPoolAddrs(ctx context.Context, addresses []*gtmAddress) ([]*alicloudDnsClient.UpdateDnsGtmAddressPoolRequestAddr, error) {
+	addrs := make([]*alicloudDnsClient.UpdateDnsGtmAddressPoolRequestAddr, 0, len(addresses))
+	for _, address := range addresses {
+		attributeInfo := &gtmAddressAttributeInfo{
+			LineCodeRectifyType: "AUTO",
+		}
+		if diags := address.Lines.ElementsAs(ctx, &attributeInfo.LineCodes, false); diags.HasError() {
+			return nil, fmt.Errorf("invalid lines of address %s", address.Address.ValueString())
+		}
+		attributeInfoJson, err := json.Marshal(attributeInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		addr := &alicloudDnsClient.UpdateDnsGtmAddressPoolRequestAddr{
+			Addr:          tea.String(address.Address.ValueString()),
+			AttributeInfo: tea.String(string(attributeInfoJson)),
+			Mode:          tea.String(address.Mode.ValueString()),
+		}
+		if !address.Weight.IsNull() {
+			addr.LbaWeight = tea.Int32(int32(address.Weight.ValueInt64()))
+		}
+		if !address.Remark.IsNull() {
+			addr.Remark = tea.String(address.Remark.ValueString())
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+func getGtmMonitorStatus(enabled bool) string {
+	if enabled {
+		return "OPEN"
+	}
+	return "CLOSE"
+}
+
+// isAliDnsGtmNotFoundError returns whether the error is returned for a GTM
+// resource that does not exist.
+func isAliDnsGtmNotFoundError(err error) bool {
+	if _t, ok := err.(*tea.SDKError); ok {
+		code := tea.StringValue(_t.Code)
+		return strings.Contains(code, "NotExist") || strings.Contains(code, "NoExist")
+	}
+	return false
+}
+
+// isJsonEqual returns whether the JSON strings are semantically equal.
+func isJsonEqual(a, b string) bool {
+	var aValue, bValue interface{}
+	if err := json.Unmarshal([]byte(a), &aValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &bValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
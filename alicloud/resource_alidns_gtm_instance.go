@@ -14,8 +14,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -83,8 +85,10 @@ type alidnsGtmInstanceResourceModel struct {
 	AlertGroup      types.List     `tfsdk:"alert_group"`
 	ResourceGroupID types.String   `tfsdk:"resource_group_id"`
 	AlertConfig     []*alertConfig `tfsdk:"alert_config"`
+	Period          types.Int64    `tfsdk:"period"`
 	RenewPeriod     types.Int64    `tfsdk:"renew_period"`
 	RenewalStatus   types.String   `tfsdk:"renewal_status"`
+	OnDestroy       types.String   `tfsdk:"on_destroy"`
 
 	// Optional
 	Id          types.String `tfsdk:"id"`
@@ -176,13 +180,46 @@ func (r *alidnsGtmInstanceResource) Schema(_ context.Context, req resource.Schem
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"period": schema.Int64Attribute{
+				Description: "The purchase period of the instance, the unit is month. Valid values: 1 to 9, " +
+					"12, 24, 36. Default to 1. Changing period has no effect once the instance is created.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.OneOf(getSubscriptionPeriods()...),
+				},
+			},
 			"renewal_status": schema.StringAttribute{
-				Description: "Automatic renewal status.",
-				Computed:    true,
+				Description: "The renewal status of the instance. Valid values: AutoRenewal, ManualRenewal, " +
+					"NotRenewal. Default to AutoRenewal.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("AutoRenewal"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("AutoRenewal", "ManualRenewal", "NotRenewal"),
+				},
 			},
 			"renew_period": schema.Int64Attribute{
-				Description: "Automatic renewal period, the unit is month.",
-				Computed:    true,
+				Description: "The automatic renewal period when renewal_status is AutoRenewal, the unit is " +
+					"month. Valid values: 1, 2, 3, 6, 12, 24, 36. Default to 1.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.OneOf(getRenewalPeriods()...),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				Description: "The renewal of the instance when the resource is destroyed, as the subscription " +
+					"instance cannot be deleted. Valid values: not_renewal to let the instance be released on " +
+					"expiration, manual_renewal, keep to leave the renewal unchanged. Default to not_renewal.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("not_renewal"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("not_renewal", "manual_renewal", "keep"),
+				},
 			},
 			"public_cname_mode": schema.StringAttribute{
 				Description: "The Public Network domain name access method. Valid " +
@@ -320,12 +357,18 @@ func (r *alidnsGtmInstanceResource) Create(ctx context.Context, req resource.Cre
 
 	//////////////////////// CREATE INSTANCE ////////////////////////
 	createInstanceRequest := &alicloudBaseClient.CreateInstanceRequest{
-		RenewalStatus:    tea.String(plan.RenewalStatus.ValueString()),
-		RenewPeriod:      tea.Int32(int32(plan.RenewPeriod.ValueInt64())),
 		SubscriptionType: tea.String(plan.PaymentType.ValueString()),
-		Period:           tea.Int32(1),
+		Period:           tea.Int32(int32(plan.Period.ValueInt64())),
 		ProductCode:      tea.String("dns"),
 	}
+	// NotRenewal is not accepted on creation, it is set after the instance is
+	// created.
+	if plan.RenewalStatus.ValueString() == "AutoRenewal" {
+		createInstanceRequest.RenewalStatus = tea.String("AutoRenewal")
+		createInstanceRequest.RenewPeriod = tea.Int32(int32(plan.RenewPeriod.ValueInt64()))
+	} else {
+		createInstanceRequest.RenewalStatus = tea.String("ManualRenewal")
+	}
 	accountType := plan.InstanceType.ValueString()
 	if accountType == "cn" {
 		if plan.SmsNotificationCount.IsNull() || plan.SmsNotificationCount.IsUnknown() {
@@ -336,7 +379,7 @@ func (r *alidnsGtmInstanceResource) Create(ctx context.Context, req resource.Cre
 			)
 			return
 		}
-		r.baseClient.Endpoint = tea.String("business.aliyuncs.com")
+		r.baseClient.Endpoint = tea.String(getGtmInstanceBillingEndpoint(accountType))
 		createInstanceRequest.ProductType = tea.String("dns_gtm_public_cn")
 		createInstanceRequest.Parameter = []*alicloudBaseClient.CreateInstanceRequestParameter{
 			{
@@ -353,7 +396,7 @@ func (r *alidnsGtmInstanceResource) Create(ctx context.Context, req resource.Cre
 			},
 		}
 	} else {
-		r.baseClient.Endpoint = tea.String("business.ap-southeast-1.aliyuncs.com")
+		r.baseClient.Endpoint = tea.String(getGtmInstanceBillingEndpoint(accountType))
 		createInstanceRequest.ProductType = tea.String("dns_gtm_public_intl")
 		createInstanceRequest.Parameter = []*alicloudBaseClient.CreateInstanceRequestParameter{
 			{
@@ -416,8 +459,10 @@ func (r *alidnsGtmInstanceResource) Create(ctx context.Context, req resource.Cre
 	}
 
 	instanceId := *createInstanceResponse.Body.Data.InstanceId
-	state.RenewalStatus = plan.RenewalStatus
+	state.RenewalStatus = types.StringValue(*createInstanceRequest.RenewalStatus)
 	state.RenewPeriod = plan.RenewPeriod
+	state.Period = plan.Period
+	state.OnDestroy = plan.OnDestroy
 	state.Id = types.StringValue(instanceId)
 	state.InstanceType = plan.InstanceType
 	state.PaymentType = plan.PaymentType
@@ -475,14 +520,6 @@ func (r *alidnsGtmInstanceResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	if state.RenewalStatus.ValueString() != "AutoRenewal" || state.RenewPeriod.ValueInt64() != 1 {
-		resp.Diagnostics.AddWarning(
-			"Auto Renewal Status Changed Outside Terraform",
-			"The renewal will be forced update to AutoRenewal and 1 month auto "+
-				"renew period after applying changes update.",
-		)
-	}
-
 	setStateDiags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(setStateDiags...)
 	if resp.Diagnostics.HasError() {
@@ -504,6 +541,13 @@ func (r *alidnsGtmInstanceResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	if !plan.Period.Equal(state.Period) {
+		resp.Diagnostics.AddWarning(
+			"[Input Warning] Changing period have no effect",
+			"Changing period have no effect to the instance once the instance is built.",
+		)
+	}
+
 	/*
 		Do not check resp.Diagnostics.HasError() so that Terraform will update its
 		state as the update process include three API calls.
@@ -527,23 +571,22 @@ func (r *alidnsGtmInstanceResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	renewalStatus := getOnDestroyRenewalStatus(state.OnDestroy.ValueString())
+	if renewalStatus == "" {
+		return
+	}
+
 	setRenewalRequest := &alicloudBaseClient.SetRenewalRequest{
 		InstanceIDs:   tea.String(state.Id.ValueString()),
-		RenewalStatus: tea.String("NotRenewal"),
+		RenewalStatus: tea.String(renewalStatus),
 		ProductCode:   tea.String("dns"),
 	}
 
-	var clientEndpoint string
-	if state.InstanceType.ValueString() == "cn" {
-		clientEndpoint = "business.aliyuncs.com"
-	} else {
-		clientEndpoint = "business.ap-southeast-1.aliyuncs.com"
-	}
-	err := r.setInstanceRenewal(clientEndpoint, setRenewalRequest)
+	err := r.setInstanceRenewal(getGtmInstanceBillingEndpoint(state.InstanceType.ValueString()), setRenewalRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"[API ERROR] Failed to Set GTM Manual Renewal",
-			fmt.Sprint(state),
+			"[API ERROR] Failed to Set GTM Instance Renewal",
+			err.Error(),
 		)
 	}
 }
@@ -555,12 +598,28 @@ func (r *alidnsGtmInstanceResource) ImportState(ctx context.Context, req resourc
 func (r *alidnsGtmInstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	// If the entire plan is null, the resource is planned for destruction.
 	if req.Plan.Raw.IsNull() {
-		resp.Diagnostics.AddWarning(
-			"Cannot destroy AliCloud GTM Instance",
-			"Terraform will not destroy AliCloud GTM Instance as the instance is "+
-				"subscription based. Instead, Terraform will set the renewal status "+
-				"to NotRenewal so that the instance will being deleted on expiration date",
-		)
+		var onDestroy types.String
+		getOnDestroyDiags := req.State.GetAttribute(ctx, path.Root("on_destroy"), &onDestroy)
+		resp.Diagnostics.Append(getOnDestroyDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		switch renewalStatus := getOnDestroyRenewalStatus(onDestroy.ValueString()); renewalStatus {
+		case "":
+			resp.Diagnostics.AddWarning(
+				"Cannot destroy AliCloud GTM Instance",
+				"Terraform will not destroy AliCloud GTM Instance as the instance is "+
+					"subscription based. The renewal status is kept as on_destroy is keep.",
+			)
+		default:
+			resp.Diagnostics.AddWarning(
+				"Cannot destroy AliCloud GTM Instance",
+				"Terraform will not destroy AliCloud GTM Instance as the instance is "+
+					"subscription based. Instead, Terraform will set the renewal status "+
+					"to "+renewalStatus+" as configured in on_destroy.",
+			)
+		}
 	} else {
 		var plan *alidnsGtmInstanceResourceModel
 		getPlanDiags := req.Plan.Get(ctx, &plan)
@@ -624,16 +683,6 @@ func (r *alidnsGtmInstanceResource) ModifyPlan(ctx context.Context, req resource
 			}
 		}
 
-		/*
-			Force the plan attributes' value of the following:
-				RenewalStatus = "AutoRenewal"
-				RenewPeriod   = 1
-			So that when detected changes outside Terraform, it will be auto
-			reverted back to AutoRenewal on AliCloud.
-		*/
-		plan.RenewPeriod = types.Int64Value(1)
-		plan.RenewalStatus = types.StringValue("AutoRenewal")
-
 		resp.Plan.Set(ctx, &plan)
 		if resp.Diagnostics.HasError() {
 			return
@@ -677,40 +726,11 @@ func (r *alidnsGtmInstanceResource) readGtmInstance(state *alidnsGtmInstanceReso
 		}
 	}
 
-	var accountType string
-	if describeDnsGtmInstanceResponse.Body.UsedQuota.SmsUsedCount == nil {
-		accountType = "intl"
-		r.baseClient.Endpoint = tea.String("business.ap-southeast-1.aliyuncs.com")
-	} else {
-		accountType = "cn"
-		r.baseClient.Endpoint = tea.String("business.aliyuncs.com")
-	}
-
-	queryAvailableInstancesRequest := &alicloudBaseClient.QueryAvailableInstancesRequest{
-		InstanceIDs: tea.String(*describeDnsGtmInstanceResponse.Body.InstanceId),
-	}
-	queryAvailableInstancesResponse := &alicloudBaseClient.QueryAvailableInstancesResponse{}
-	queryGtmInstance := func() error {
-		runtime := &util.RuntimeOptions{}
-		queryAvailableInstancesResponse, err = r.baseClient.QueryAvailableInstancesWithOptions(queryAvailableInstancesRequest, runtime)
-		if err != nil {
-			if _t, ok := err.(*tea.SDKError); ok {
-				if isAbleToRetry(*_t.Code) {
-					return err
-				} else {
-					return backoff.Permanent(err)
-				}
-			} else {
-				return err
-			}
-		}
-		return nil
-	}
-
-	// Retry backoff
-	reconnectBackoff = backoff.NewExponentialBackOff()
-	reconnectBackoff.MaxElapsedTime = 30 * time.Second
-	err = backoff.Retry(queryGtmInstance, reconnectBackoff)
+	/*
+		The instance type is unknown on import, in which case both billing
+		endpoints are probed to detect whether it is a cn or intl instance.
+	*/
+	instance, accountType, err := r.queryGtmInstanceBilling(state.Id.ValueString(), state.InstanceType.ValueString())
 	if err != nil {
 		return diag.Diagnostics{
 			diag.NewErrorDiagnostic(
@@ -720,21 +740,30 @@ func (r *alidnsGtmInstanceResource) readGtmInstance(state *alidnsGtmInstanceReso
 		}
 	}
 
-	var renewalStatus string
-	var renewalDuration int32
-	renewalStatus = *queryAvailableInstancesResponse.Body.Data.InstanceList[0].RenewStatus
+	/*
+		The renewal period is only returned for AutoRenewal, otherwise the
+		configured period is kept so that it does not show a difference.
+	*/
+	renewalStatus := tea.StringValue(instance.RenewStatus)
 	if renewalStatus == "AutoRenewal" {
-		if *queryAvailableInstancesResponse.Body.Data.InstanceList[0].RenewalDurationUnit == "Y" {
-			renewalDuration = *queryAvailableInstancesResponse.Body.Data.InstanceList[0].RenewalDuration * 12
-		} else {
-			renewalDuration = *queryAvailableInstancesResponse.Body.Data.InstanceList[0].RenewalDuration
+		renewalDuration := int64(tea.Int32Value(instance.RenewalDuration))
+		if tea.StringValue(instance.RenewalDurationUnit) == "Y" {
+			renewalDuration *= 12
 		}
-	} else {
-		renewalDuration = 0
+		state.RenewPeriod = types.Int64Value(renewalDuration)
+	} else if state.RenewPeriod.IsNull() || state.RenewPeriod.IsUnknown() {
+		state.RenewPeriod = types.Int64Value(1)
 	}
-
-	state.RenewPeriod = types.Int64Value(int64(renewalDuration))
 	state.RenewalStatus = types.StringValue(renewalStatus)
+
+	// The purchase period and on_destroy are not returned, defaults are used
+	// on import.
+	if state.Period.IsNull() || state.Period.IsUnknown() {
+		state.Period = types.Int64Value(1)
+	}
+	if state.OnDestroy.IsNull() || state.OnDestroy.IsUnknown() {
+		state.OnDestroy = types.StringValue("not_renewal")
+	}
 	state.InstanceType = types.StringValue(accountType)
 	state.Id = types.StringValue(*describeDnsGtmInstanceResponse.Body.InstanceId)
 	state.ResourceGroupID = types.StringValue(*describeDnsGtmInstanceResponse.Body.ResourceGroupId)
@@ -800,34 +829,34 @@ func (r *alidnsGtmInstanceResource) readGtmInstance(state *alidnsGtmInstanceReso
 func (r *alidnsGtmInstanceResource) updateGtmInstance(plan *alidnsGtmInstanceResourceModel, state *alidnsGtmInstanceResourceModel) diag.Diagnostics {
 	var err error
 
-	// SetRenewal
-	if state.RenewalStatus.ValueString() != "AutoRenewal" || state.RenewPeriod.ValueInt64() != 1 {
-		var clientEndpoint string
-		if state.InstanceType.ValueString() == "cn" {
-			clientEndpoint = "business.aliyuncs.com"
-		} else {
-			clientEndpoint = "business.ap-southeast-1.aliyuncs.com"
-		}
+	state.Period = plan.Period
+	state.OnDestroy = plan.OnDestroy
 
+	// SetRenewal
+	if !plan.RenewalStatus.Equal(state.RenewalStatus) ||
+		(plan.RenewalStatus.ValueString() == "AutoRenewal" && !plan.RenewPeriod.Equal(state.RenewPeriod)) {
 		setRenewalRequest := &alicloudBaseClient.SetRenewalRequest{
-			InstanceIDs:       tea.String(state.Id.ValueString()),
-			RenewalStatus:     tea.String("AutoRenewal"),
-			RenewalPeriod:     tea.Int32(1),
-			RenewalPeriodUnit: tea.String("M"),
-			ProductCode:       tea.String("dns"),
+			InstanceIDs:   tea.String(state.Id.ValueString()),
+			RenewalStatus: tea.String(plan.RenewalStatus.ValueString()),
+			ProductCode:   tea.String("dns"),
+		}
+		if plan.RenewalStatus.ValueString() == "AutoRenewal" {
+			renewalPeriod, renewalPeriodUnit := getRenewalPeriodAndUnit(plan.RenewPeriod.ValueInt64())
+			setRenewalRequest.RenewalPeriod = tea.Int32(renewalPeriod)
+			setRenewalRequest.RenewalPeriodUnit = tea.String(renewalPeriodUnit)
 		}
 
-		err = r.setInstanceRenewal(clientEndpoint, setRenewalRequest)
+		err = r.setInstanceRenewal(getGtmInstanceBillingEndpoint(state.InstanceType.ValueString()), setRenewalRequest)
 		if err != nil {
 			return diag.Diagnostics{
 				diag.NewErrorDiagnostic(
-					"[API ERROR] Failed to Set GTM Auto Renewal",
+					"[API ERROR] Failed to Set GTM Instance Renewal",
 					err.Error(),
 				),
 			}
 		}
-		state.RenewalStatus = types.StringValue("AutoRenewal")
-		state.RenewPeriod = types.Int64Value(1)
+		state.RenewalStatus = plan.RenewalStatus
+		state.RenewPeriod = plan.RenewPeriod
 	}
 
 	// MoveGtmResourceGroupWithOptions
@@ -1014,3 +1043,65 @@ func (r alidnsGtmInstanceResource) setInstanceRenewal(clientEndpoint string, req
 	reconnectBackoff.MaxElapsedTime = 30 * time.Second
 	return backoff.Retry(setRenewal, reconnectBackoff)
 }
+
+// getGtmInstanceBillingEndpoint returns the billing endpoint of the type of
+// Global Traffic Manager instance.
+func getGtmInstanceBillingEndpoint(instanceType string) string {
+	if instanceType == "cn" {
+		return "business.aliyuncs.com"
+	}
+	return "business.ap-southeast-1.aliyuncs.com"
+}
+
+// queryGtmInstanceBilling queries the billing information of the instance on
+// the billing endpoint of the instance type, and returns it together with the
+// instance type. If the instance type is empty, the cn and intl billing
+// endpoints are both probed, and the instance type is detected from the
+// endpoint where the instance is found.
+func (r *alidnsGtmInstanceResource) queryGtmInstanceBilling(instanceId, instanceType string) (*alicloudBaseClient.QueryAvailableInstancesResponseBodyDataInstanceList, string, error) {
+	instanceTypes := []string{instanceType}
+	if instanceType == "" {
+		instanceTypes = []string{"cn", "intl"}
+	}
+
+	var probeErr error
+	for _, instanceType := range instanceTypes {
+		r.baseClient.Endpoint = tea.String(getGtmInstanceBillingEndpoint(instanceType))
+
+		var queryAvailableInstancesResponse *alicloudBaseClient.QueryAvailableInstancesResponse
+		queryGtmInstance := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			queryAvailableInstancesRequest := &alicloudBaseClient.QueryAvailableInstancesRequest{
+				InstanceIDs: tea.String(instanceId),
+				ProductCode: tea.String("dns"),
+			}
+
+			var err error
+			queryAvailableInstancesResponse, err = r.baseClient.QueryAvailableInstancesWithOptions(queryAvailableInstancesRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(queryGtmInstance, reconnectBackoff); err != nil {
+			// The billing endpoint of the other site may reject the account.
+			probeErr = err
+			continue
+		}
+
+		body := queryAvailableInstancesResponse.Body
+		if body.Data != nil && len(body.Data.InstanceList) > 0 {
+			return body.Data.InstanceList[0], instanceType, nil
+		}
+	}
+
+	if probeErr != nil {
+		return nil, "", probeErr
+	}
+	return nil, "", fmt.Errorf("the billing information of GTM instance %s is not found", instanceId)
+}
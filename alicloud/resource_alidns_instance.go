@@ -47,6 +47,7 @@ type alidnsInstanceResourceModel struct {
 	Period        types.Int64  `tfsdk:"period"`
 	RenewPeriod   types.Int64  `tfsdk:"renew_period"`
 	RenewalStatus types.String `tfsdk:"renewal_status"`
+	OnDestroy     types.String `tfsdk:"on_destroy"`
 	VersionCode   types.String `tfsdk:"version_code"`
 }
 
@@ -91,33 +92,44 @@ func (r *alidnsInstanceResource) Schema(_ context.Context, _ resource.SchemaRequ
 				},
 			},
 			"period": schema.Int64Attribute{
-				Description: "Creating a pre-paid instance, it must be set, the unit is month," +
-					"please enter an integer multiple of 12 for annually paid products.",
+				Description: "Creating a pre-paid instance, it must be set, the unit is month. Valid values: " +
+					"1 to 9, 12, 24, 36.",
 				Required: true,
 				Validators: []validator.Int64{
-					int64validator.AtLeast(1),
-					int64validator.AtMost(12),
+					int64validator.OneOf(getSubscriptionPeriods()...),
 				},
 			},
 			"renew_period": schema.Int64Attribute{
-				Description: "Automatic renewal period, the unit is month. When setting RenewalStatus to AutoRenewal, it must be set.",
-				Optional:    true,
-				Computed:    true,
+				Description: "Automatic renewal period, the unit is month. Valid values: 1, 2, 3, 6, 12, 24, 36. " +
+					"When setting renewal_status to AutoRenewal, it must be set.",
+				Optional: true,
+				Computed: true,
 				Validators: []validator.Int64{
-					int64validator.AtLeast(1),
-					int64validator.AtMost(12),
+					int64validator.OneOf(getRenewalPeriods()...),
 				},
 				Default: int64default.StaticInt64(0),
 			},
 			"renewal_status": schema.StringAttribute{
-				Description: "Automatic renewal status. Valid values: AutoRenewal, ManualRenewal, default to ManualRenewal.",
-				Computed:    true,
-				Optional:    true,
+				Description: "Automatic renewal status. Valid values: AutoRenewal, ManualRenewal, NotRenewal, " +
+					"default to ManualRenewal.",
+				Computed: true,
+				Optional: true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("AutoRenewal", "ManualRenewal"),
+					stringvalidator.OneOf("AutoRenewal", "ManualRenewal", "NotRenewal"),
 				},
 				Default: stringdefault.StaticString("ManualRenewal"),
 			},
+			"on_destroy": schema.StringAttribute{
+				Description: "The renewal of the instance when the resource is destroyed, as the subscription " +
+					"instance cannot be deleted. Valid values: not_renewal to let the instance be released on " +
+					"expiration, manual_renewal, keep to leave the renewal unchanged. Default to not_renewal.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("not_renewal"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("not_renewal", "manual_renewal", "keep"),
+				},
+			},
 			"version_code": schema.StringAttribute{
 				Description: "Paid package version. Valid values: version_personal, version_enterprise_basic, version_enterprise_advanced.",
 				Required:    true,
@@ -153,6 +165,11 @@ func (r *alidnsInstanceResource) Create(ctx context.Context, req resource.Create
 		Period:           tea.Int32(int32(plan.Period.ValueInt64())),
 		RenewalStatus:    tea.String(plan.RenewalStatus.ValueString()),
 	}
+	// NotRenewal is not accepted on creation, it is set after the instance is
+	// created.
+	if plan.RenewalStatus.ValueString() == "NotRenewal" {
+		createAlidnsInstanceRequest.RenewalStatus = tea.String("ManualRenewal")
+	}
 	createAlidnsInstanceRequest.Parameter = []*alicloudBaseClient.CreateInstanceRequestParameter{
 		{
 			Code:  tea.String("InstanceType"),
@@ -172,13 +189,14 @@ func (r *alidnsInstanceResource) Create(ctx context.Context, req resource.Create
 		},
 	}
 
-	if plan.RenewalStatus.ValueString() == "AutoRenewal" && plan.RenewPeriod.IsNull() {
-		resp.Diagnostics.AddError(
-			"[API ERROR] Failed to Create AliDNS Instance",
-			"renew_period is required when AutoRenewal is set in renewal_status.",
-		)
-		return
-	} else {
+	if plan.RenewalStatus.ValueString() == "AutoRenewal" {
+		if plan.RenewPeriod.ValueInt64() == 0 {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Create AliDNS Instance",
+				"renew_period is required when AutoRenewal is set in renewal_status.",
+			)
+			return
+		}
 		createAlidnsInstanceRequest.RenewPeriod = tea.Int32(int32(plan.RenewPeriod.ValueInt64()))
 	}
 
@@ -222,11 +240,35 @@ func (r *alidnsInstanceResource) Create(ctx context.Context, req resource.Create
 	state = &alidnsInstanceResourceModel{}
 	state = plan
 	state.InstanceId = types.StringValue(*createInstanceResponse.Body.Data.InstanceId)
+	state.RenewalStatus = types.StringValue(*createAlidnsInstanceRequest.RenewalStatus)
 	setStateDiags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(setStateDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if !plan.RenewalStatus.Equal(state.RenewalStatus) {
+		setRenewalRequest := &alicloudBaseClient.SetRenewalRequest{
+			InstanceIDs:   tea.String(state.InstanceId.ValueString()),
+			RenewalStatus: tea.String(plan.RenewalStatus.ValueString()),
+			ProductCode:   tea.String("dns"),
+			ProductType:   tea.String("dns_dns_public_intl"),
+		}
+		if err := r.setInstanceRenewal(setRenewalRequest); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set DNS Instance Renewal",
+				err.Error(),
+			)
+			return
+		}
+
+		state.RenewalStatus = plan.RenewalStatus
+		setStateDiags := resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(setStateDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 }
 
 func (r *alidnsInstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -296,24 +338,27 @@ func (r *alidnsInstanceResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	switch *describeRsp.Body.DnsSecurity {
-	case "Not Required":
-		state.DnsSecurity = types.StringValue("no")
-	case "DNS Attack Defense Basic":
-		state.DnsSecurity = types.StringValue("basic")
-	case "DNS Anti-DDoS Basic":
-		state.DnsSecurity = types.StringValue("basic")
-	case "DNS Anti-DDoS Advanced":
-		state.DnsSecurity = types.StringValue("advanced")
+	if dnsSecurity := getAlidnsInstanceDnsSecurity(*describeRsp.Body.DnsSecurity); dnsSecurity != "" {
+		state.DnsSecurity = types.StringValue(dnsSecurity)
 	}
 	state.DomainNumbers = types.Int64Value(*describeRsp.Body.BindDomainCount)
 	state.PaymentType = types.StringValue(*describeRsp.Body.PaymentType)
-	if queryRsp.Body.Data.InstanceList[0].RenewalDuration == nil {
+	// The renewal period is only returned for AutoRenewal, otherwise the
+	// configured period is kept so that it does not show a difference.
+	billingInstance := queryRsp.Body.Data.InstanceList[0]
+	if tea.StringValue(billingInstance.RenewStatus) == "AutoRenewal" && billingInstance.RenewalDuration != nil {
+		renewalDuration := int64(*billingInstance.RenewalDuration)
+		if tea.StringValue(billingInstance.RenewalDurationUnit) == "Y" {
+			renewalDuration *= 12
+		}
+		state.RenewPeriod = types.Int64Value(renewalDuration)
+	} else if state.RenewPeriod.IsNull() {
 		state.RenewPeriod = types.Int64Value(0)
-	} else {
-		state.RenewPeriod = types.Int64Value(int64(*queryRsp.Body.Data.InstanceList[0].RenewalDuration))
 	}
-	state.RenewalStatus = types.StringValue(*queryRsp.Body.Data.InstanceList[0].RenewStatus)
+	state.RenewalStatus = types.StringValue(tea.StringValue(billingInstance.RenewStatus))
+	if state.OnDestroy.IsNull() {
+		state.OnDestroy = types.StringValue("not_renewal")
+	}
 	state.VersionCode = types.StringValue(*describeRsp.Body.VersionCode)
 
 	setStateDiags := resp.State.Set(ctx, &state)
@@ -399,21 +444,40 @@ func (r *alidnsInstanceResource) Update(ctx context.Context, req resource.Update
 		)
 	}
 
+	if plan.RenewalStatus.ValueString() == "AutoRenewal" && plan.RenewPeriod.ValueInt64() == 0 {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Upgrade AliDNS Instance",
+			"renew_period is required when AutoRenewal is set in renewal_status.",
+		)
+		return
+	}
+
 	//////////////////////// UPGRADE INSTANCE ////////////////////////
 	// Modify renewal status if changes detected
-	setRenewalRequest := &alicloudBaseClient.SetRenewalRequest{
-		InstanceIDs:   tea.String(state.InstanceId.ValueString()),
-		RenewalStatus: tea.String(plan.RenewalStatus.ValueString()),
-		ProductCode:   tea.String("dns"),
-		ProductType:   tea.String("dns_dns_public_intl"),
-	}
 	var err error
-	err = r.setInstanceRenewal(setRenewalRequest)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"[API ERROR] Failed to Disable DNS Instance Renewal",
-			fmt.Sprint(state),
-		)
+	if !plan.RenewalStatus.Equal(state.RenewalStatus) ||
+		(plan.RenewalStatus.ValueString() == "AutoRenewal" && !plan.RenewPeriod.Equal(state.RenewPeriod)) {
+		setRenewalRequest := &alicloudBaseClient.SetRenewalRequest{
+			InstanceIDs:   tea.String(state.InstanceId.ValueString()),
+			RenewalStatus: tea.String(plan.RenewalStatus.ValueString()),
+			ProductCode:   tea.String("dns"),
+			ProductType:   tea.String("dns_dns_public_intl"),
+		}
+		if plan.RenewalStatus.ValueString() == "AutoRenewal" {
+			renewalPeriod, renewalPeriodUnit := getRenewalPeriodAndUnit(plan.RenewPeriod.ValueInt64())
+			setRenewalRequest.RenewalPeriod = tea.Int32(renewalPeriod)
+			setRenewalRequest.RenewalPeriodUnit = tea.String(renewalPeriodUnit)
+		}
+		err = r.setInstanceRenewal(setRenewalRequest)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Set DNS Instance Renewal",
+				err.Error(),
+			)
+			return
+		}
+		state.RenewPeriod = plan.RenewPeriod
+		state.RenewalStatus = plan.RenewalStatus
 	}
 
 	modifyInstanceResponse := &alicloudBaseClient.ModifyInstanceResponse{}
@@ -457,9 +521,8 @@ func (r *alidnsInstanceResource) Update(ctx context.Context, req resource.Update
 	state.DnsSecurity = plan.DnsSecurity
 	state.DomainNumbers = plan.DomainNumbers
 	state.VersionCode = plan.VersionCode
-	state.RenewPeriod = plan.RenewPeriod
-	state.RenewalStatus = plan.RenewalStatus
 	state.Period = plan.Period
+	state.OnDestroy = plan.OnDestroy
 	setStateDiags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(setStateDiags...)
 	if resp.Diagnostics.HasError() {
@@ -475,9 +538,14 @@ func (r *alidnsInstanceResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	renewalStatus := getOnDestroyRenewalStatus(state.OnDestroy.ValueString())
+	if renewalStatus == "" {
+		return
+	}
+
 	setRenewalRequest := &alicloudBaseClient.SetRenewalRequest{
 		InstanceIDs:   tea.String(state.InstanceId.ValueString()),
-		RenewalStatus: tea.String("NotRenewal"),
+		RenewalStatus: tea.String(renewalStatus),
 		ProductCode:   tea.String("dns"),
 		ProductType:   tea.String("dns_dns_public_intl"),
 	}
@@ -485,8 +553,8 @@ func (r *alidnsInstanceResource) Delete(ctx context.Context, req resource.Delete
 	err := r.setInstanceRenewal(setRenewalRequest)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"[API ERROR] Failed to Disable DNS Instance Renewal",
-			fmt.Sprint(state),
+			"[API ERROR] Failed to Set DNS Instance Renewal",
+			err.Error(),
 		)
 	}
 }
@@ -495,6 +563,22 @@ func (r *alidnsInstanceResource) ImportState(ctx context.Context, req resource.I
 	resource.ImportStatePassthroughID(ctx, path.Root("instance_id"), req, resp)
 }
 
+// getAlidnsInstanceDnsSecurity converts the DNS security name returned by
+// the API into the dns_security value, or an empty string if it is unknown.
+func getAlidnsInstanceDnsSecurity(dnsSecurity string) string {
+	switch dnsSecurity {
+	case "Not Required":
+		return "no"
+	case "DNS Attack Defense Basic":
+		return "basic"
+	case "DNS Anti-DDoS Basic":
+		return "basic"
+	case "DNS Anti-DDoS Advanced":
+		return "advanced"
+	}
+	return ""
+}
+
 func (r alidnsInstanceResource) setInstanceRenewal(req *alicloudBaseClient.SetRenewalRequest) error {
 	setRenewal := func() error {
 		runtime := &util.RuntimeOptions{}
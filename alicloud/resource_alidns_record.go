@@ -0,0 +1,726 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &aliDnsRecordResource{}
+	_ resource.ResourceWithConfigure   = &aliDnsRecordResource{}
+	_ resource.ResourceWithImportState = &aliDnsRecordResource{}
+)
+
+func NewAliDnsRecordResource() resource.Resource {
+	return &aliDnsRecordResource{}
+}
+
+type aliDnsRecordResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsRecordResourceModel struct {
+	RecordId   types.String `tfsdk:"record_id"`
+	DomainName types.String `tfsdk:"domain_name"`
+	RR         types.String `tfsdk:"rr"`
+	Type       types.String `tfsdk:"type"`
+	Value      types.String `tfsdk:"value"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+	Line       types.String `tfsdk:"line"`
+	Priority   types.Int64  `tfsdk:"priority"`
+	Remark     types.String `tfsdk:"remark"`
+	Status     types.String `tfsdk:"status"`
+	Weight     types.Int64  `tfsdk:"weight"`
+}
+
+// Metadata returns the resource DNS record type name.
+func (r *aliDnsRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_record"
+}
+
+// Schema defines the schema for the DNS record resource.
+func (r *aliDnsRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alidns record resource, with the weighted round robin of the subdomain " +
+			"managed together with the record.",
+		Attributes: map[string]schema.Attribute{
+			"record_id": schema.StringAttribute{
+				Description: "Record Id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description: "Domain Name of the record.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rr": schema.StringAttribute{
+				Description: "Host Record (RR) of the record, use @ for the domain itself.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Record Type. Valid values: A, AAAA, CNAME, MX, TXT, NS, SRV, CAA, REDIRECT_URL, FORWARD_URL.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA", "REDIRECT_URL", "FORWARD_URL"),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "Record Value.",
+				Required:    true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "Time to live of the record in seconds. Default to 600.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 86400),
+				},
+				Default: int64default.StaticInt64(600),
+			},
+			"line": schema.StringAttribute{
+				Description: "Resolution Line of the record. Default to default.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("default"),
+			},
+			"priority": schema.Int64Attribute{
+				Description: "Priority of the MX record. Valid values: 1 to 50.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 50),
+				},
+			},
+			"remark": schema.StringAttribute{
+				Description: "Remark of the record.",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the record. Valid values: ENABLE, DISABLE. Default to ENABLE.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ENABLE", "DISABLE"),
+				},
+				Default: stringdefault.StaticString("ENABLE"),
+			},
+			"weight": schema.Int64Attribute{
+				Description: "Weight of the record in the weighted round robin of the subdomain. " +
+					"Weighted round robin is enabled for the subdomain when it is set. Valid values: 1 to 100.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 100),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aliDnsRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+// Create a new DNS record resource
+func (r *aliDnsRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan *aliDnsRecordResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var recordId string
+	addDomainRecord := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		addDomainRecordRequest := &alicloudDnsClient.AddDomainRecordRequest{
+			DomainName: tea.String(plan.DomainName.ValueString()),
+			RR:         tea.String(plan.RR.ValueString()),
+			Type:       tea.String(plan.Type.ValueString()),
+			Value:      tea.String(plan.Value.ValueString()),
+			TTL:        tea.Int64(plan.TTL.ValueInt64()),
+			Line:       tea.String(plan.Line.ValueString()),
+		}
+		if !plan.Priority.IsNull() {
+			addDomainRecordRequest.Priority = tea.Int64(plan.Priority.ValueInt64())
+		}
+
+		addDomainRecordResponse, err := r.client.AddDomainRecordWithOptions(addDomainRecordRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		recordId = tea.StringValue(addDomainRecordResponse.Body.RecordId)
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(addDomainRecord, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add DNS Record",
+			err.Error(),
+		)
+		return
+	}
+
+	// Save the record ID first, so that the record is not leaked if any of
+	// the following settings fails.
+	state := &aliDnsRecordResourceModel{}
+	state.RecordId = types.StringValue(recordId)
+	state.DomainName = plan.DomainName
+	state.RR = plan.RR
+	state.Type = plan.Type
+	state.Value = plan.Value
+	state.TTL = plan.TTL
+	state.Line = plan.Line
+	state.Priority = plan.Priority
+	state.Remark = types.StringNull()
+	state.Status = types.StringValue("ENABLE")
+	state.Weight = types.Int64Null()
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateRecordSettings(state, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update DNS Record Settings",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	setStateDiags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read DNS record resource information
+func (r *aliDnsRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state *aliDnsRecordResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var record *alicloudDnsClient.DescribeDomainRecordInfoResponseBody
+	describeDomainRecordInfo := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeDomainRecordInfoRequest := &alicloudDnsClient.DescribeDomainRecordInfoRequest{
+			RecordId: tea.String(state.RecordId.ValueString()),
+		}
+
+		describeDomainRecordInfoResponse, err := r.client.DescribeDomainRecordInfoWithOptions(describeDomainRecordInfoRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		record = describeDomainRecordInfoResponse.Body
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(describeDomainRecordInfo, reconnectBackoff); err != nil {
+		if isAliDnsRecordNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read DNS Record",
+			err.Error(),
+		)
+		return
+	}
+
+	// The domain name is only missing right after import, in which case the
+	// weight is adopted if weighted round robin is enabled for the subdomain.
+	importing := state.DomainName.IsNull()
+
+	state.DomainName = types.StringValue(tea.StringValue(record.DomainName))
+	state.RR = types.StringValue(tea.StringValue(record.RR))
+	state.Type = types.StringValue(tea.StringValue(record.Type))
+	state.Value = types.StringValue(tea.StringValue(record.Value))
+	state.TTL = types.Int64Value(tea.Int64Value(record.TTL))
+	state.Line = types.StringValue(tea.StringValue(record.Line))
+	state.Status = types.StringValue(tea.StringValue(record.Status))
+	if tea.StringValue(record.Type) == "MX" {
+		state.Priority = types.Int64Value(tea.Int64Value(record.Priority))
+	} else {
+		state.Priority = types.Int64Null()
+	}
+
+	// The remark and the weight are only returned when listing the records of
+	// the subdomain.
+	subDomainRecords, err := describeAliDnsSubDomainRecords(r.client, state.DomainName.ValueString(), state.RR.ValueString(), state.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read DNS Subdomain Records",
+			err.Error(),
+		)
+		return
+	}
+	for _, subDomainRecord := range subDomainRecords {
+		if tea.StringValue(subDomainRecord.RecordId) != state.RecordId.ValueString() {
+			continue
+		}
+
+		// An empty remark is kept as configured, either null or empty.
+		if remark := tea.StringValue(subDomainRecord.Remark); remark != "" || (!state.Remark.IsNull() && state.Remark.ValueString() == "") {
+			state.Remark = types.StringValue(remark)
+		} else {
+			state.Remark = types.StringNull()
+		}
+
+		if !state.Weight.IsNull() && subDomainRecord.Weight != nil {
+			state.Weight = types.Int64Value(int64(tea.Int32Value(subDomainRecord.Weight)))
+		}
+		if importing && subDomainRecord.Weight != nil {
+			open, err := getAliDnsSLBStatus(r.client, state.DomainName.ValueString(), state.RR.ValueString(), state.Type.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"[API ERROR] Failed to Read DNS SLB Status",
+					err.Error(),
+				)
+				return
+			}
+			if open {
+				state.Weight = types.Int64Value(int64(tea.Int32Value(subDomainRecord.Weight)))
+			}
+		}
+	}
+
+	// Set refreshed state
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the DNS record resource and sets the updated Terraform state on success.
+func (r *aliDnsRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *aliDnsRecordResourceModel
+
+	// Retrieve values from plan
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// UpdateDomainRecord fails if nothing is changed.
+	if !(plan.RR.Equal(state.RR) && plan.Type.Equal(state.Type) && plan.Value.Equal(state.Value) &&
+		plan.TTL.Equal(state.TTL) && plan.Line.Equal(state.Line) && plan.Priority.Equal(state.Priority)) {
+		updateDomainRecord := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			updateDomainRecordRequest := &alicloudDnsClient.UpdateDomainRecordRequest{
+				RecordId: tea.String(state.RecordId.ValueString()),
+				RR:       tea.String(plan.RR.ValueString()),
+				Type:     tea.String(plan.Type.ValueString()),
+				Value:    tea.String(plan.Value.ValueString()),
+				TTL:      tea.Int64(plan.TTL.ValueInt64()),
+				Line:     tea.String(plan.Line.ValueString()),
+			}
+			if !plan.Priority.IsNull() {
+				updateDomainRecordRequest.Priority = tea.Int64(plan.Priority.ValueInt64())
+			}
+
+			if _, err := r.client.UpdateDomainRecordWithOptions(updateDomainRecordRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(updateDomainRecord, reconnectBackoff); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update DNS Record",
+				err.Error(),
+			)
+			return
+		}
+
+		// The weighted round robin of the previous subdomain is disabled if
+		// this was its last record.
+		if !state.Weight.IsNull() && !(plan.RR.Equal(state.RR) && plan.Type.Equal(state.Type)) {
+			if err := r.closeSLBIfUnused(state); err != nil {
+				resp.Diagnostics.AddError(
+					"[API ERROR] Failed to Disable DNS SLB",
+					err.Error(),
+				)
+				return
+			}
+			// The weight of the record in the new subdomain has to be set
+			// again.
+			state.Weight = types.Int64Null()
+		}
+
+		state.RR = plan.RR
+		state.Type = plan.Type
+		state.Value = plan.Value
+		state.TTL = plan.TTL
+		state.Line = plan.Line
+		state.Priority = plan.Priority
+	}
+
+	if err := r.updateRecordSettings(state, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update DNS Record Settings",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state to plan data
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the DNS record resource and removes the Terraform state on success.
+func (r *aliDnsRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state *aliDnsRecordResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteDomainRecord := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteDomainRecordRequest := &alicloudDnsClient.DeleteDomainRecordRequest{
+			RecordId: tea.String(state.RecordId.ValueString()),
+		}
+
+		if _, err := r.client.DeleteDomainRecordWithOptions(deleteDomainRecordRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteDomainRecord, reconnectBackoff); err != nil && !isAliDnsRecordNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete DNS Record",
+			err.Error(),
+		)
+		return
+	}
+
+	if !state.Weight.IsNull() {
+		if err := r.closeSLBIfUnused(state); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Disable DNS SLB",
+				err.Error(),
+			)
+			return
+		}
+	}
+}
+
+func (r *aliDnsRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import RecordId and save to record_id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("record_id"), req, resp)
+}
+
+// updateRecordSettings applies the remark, status and weight of the plan that
+// differ from the state, and updates the state accordingly.
+func (r *aliDnsRecordResource) updateRecordSettings(state, plan *aliDnsRecordResourceModel) error {
+	runtime := &util.RuntimeOptions{}
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+
+	if !plan.Remark.Equal(state.Remark) {
+		updateDomainRecordRemark := func() error {
+			updateDomainRecordRemarkRequest := &alicloudDnsClient.UpdateDomainRecordRemarkRequest{
+				RecordId: tea.String(state.RecordId.ValueString()),
+				Remark:   tea.String(plan.Remark.ValueString()),
+			}
+
+			if _, err := r.client.UpdateDomainRecordRemarkWithOptions(updateDomainRecordRemarkRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		if err := backoff.Retry(updateDomainRecordRemark, reconnectBackoff); err != nil {
+			return err
+		}
+		state.Remark = plan.Remark
+	}
+
+	if !plan.Status.Equal(state.Status) {
+		setDomainRecordStatus := func() error {
+			setDomainRecordStatusRequest := &alicloudDnsClient.SetDomainRecordStatusRequest{
+				RecordId: tea.String(state.RecordId.ValueString()),
+				Status:   tea.String(plan.Status.ValueString()),
+			}
+
+			if _, err := r.client.SetDomainRecordStatusWithOptions(setDomainRecordStatusRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		if err := backoff.Retry(setDomainRecordStatus, reconnectBackoff); err != nil {
+			return err
+		}
+		state.Status = plan.Status
+	}
+
+	// A weight removed from the configuration is no longer managed, the
+	// weighted round robin of the subdomain is kept for the other records.
+	if plan.Weight.IsNull() {
+		state.Weight = types.Int64Null()
+	} else if !plan.Weight.Equal(state.Weight) {
+		if err := setAliDnsSLBStatus(r.client, state.DomainName.ValueString(), state.RR.ValueString(), state.Type.ValueString(), true); err != nil {
+			return err
+		}
+		if err := updateAliDnsSLBWeight(r.client, state.RecordId.ValueString(), plan.Weight.ValueInt64()); err != nil {
+			return err
+		}
+		state.Weight = plan.Weight
+	}
+
+	return nil
+}
+
+// closeSLBIfUnused disables the weighted round robin of the subdomain of the
+// record once no more than one record is left in it.
+func (r *aliDnsRecordResource) closeSLBIfUnused(state *aliDnsRecordResourceModel) error {
+	subDomainRecords, err := describeAliDnsSubDomainRecords(r.client, state.DomainName.ValueString(), state.RR.ValueString(), state.Type.ValueString())
+	if err != nil {
+		return err
+	}
+
+	remainingRecords := 0
+	for _, subDomainRecord := range subDomainRecords {
+		if tea.StringValue(subDomainRecord.RecordId) != state.RecordId.ValueString() {
+			remainingRecords++
+		}
+	}
+	if remainingRecords > 1 {
+		return nil
+	}
+
+	return setAliDnsSLBStatus(r.client, state.DomainName.ValueString(), state.RR.ValueString(), state.Type.ValueString(), false)
+}
+
+// getAliDnsSubDomainName combines the domain name and the host record (RR)
+// into the subdomain name.
+func getAliDnsSubDomainName(domainName, rr string) string {
+	return fmt.Sprintf("%s.%s", rr, domainName)
+}
+
+// isAliDnsRecordNotFoundError returns whether the error is returned for a DNS
+// record that does not exist.
+func isAliDnsRecordNotFoundError(err error) bool {
+	if _t, ok := err.(*tea.SDKError); ok {
+		switch tea.StringValue(_t.Code) {
+		case "InvalidRR.NoExist", "DomainRecordNotBelongToUser":
+			return true
+		}
+	}
+	return false
+}
+
+// describeAliDnsSubDomainRecords lists all records of the subdomain with the
+// record type.
+func describeAliDnsSubDomainRecords(client *alicloudDnsClient.Client, domainName, rr, recordType string) ([]*alicloudDnsClient.DescribeSubDomainRecordsResponseBodyDomainRecordsRecord, error) {
+	records := make([]*alicloudDnsClient.DescribeSubDomainRecordsResponseBodyDomainRecordsRecord, 0)
+
+	for pageNumber := int64(1); ; pageNumber++ {
+		var describeSubDomainRecordsResponse *alicloudDnsClient.DescribeSubDomainRecordsResponse
+		describeSubDomainRecords := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			describeSubDomainRecordsRequest := &alicloudDnsClient.DescribeSubDomainRecordsRequest{
+				DomainName: tea.String(domainName),
+				SubDomain:  tea.String(getAliDnsSubDomainName(domainName, rr)),
+				Type:       tea.String(recordType),
+				PageNumber: tea.Int64(pageNumber),
+				PageSize:   tea.Int64(500),
+			}
+
+			var err error
+			describeSubDomainRecordsResponse, err = client.DescribeSubDomainRecordsWithOptions(describeSubDomainRecordsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(describeSubDomainRecords, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		if describeSubDomainRecordsResponse.Body.DomainRecords != nil {
+			records = append(records, describeSubDomainRecordsResponse.Body.DomainRecords.Record...)
+		}
+		if int64(len(records)) >= tea.Int64Value(describeSubDomainRecordsResponse.Body.TotalCount) ||
+			describeSubDomainRecordsResponse.Body.DomainRecords == nil ||
+			len(describeSubDomainRecordsResponse.Body.DomainRecords.Record) == 0 {
+			break
+		}
+	}
+
+	return records, nil
+}
+
+// getAliDnsSLBStatus returns whether weighted round robin is enabled for the
+// subdomain with the record type.
+func getAliDnsSLBStatus(client *alicloudDnsClient.Client, domainName, rr, recordType string) (bool, error) {
+	subDomainName := getAliDnsSubDomainName(domainName, rr)
+
+	for pageNumber := int64(1); ; pageNumber++ {
+		var describeDNSSLBSubDomainsResponse *alicloudDnsClient.DescribeDNSSLBSubDomainsResponse
+		describeDNSSLBSubDomains := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			describeDNSSLBSubDomainsRequest := &alicloudDnsClient.DescribeDNSSLBSubDomainsRequest{
+				DomainName: tea.String(domainName),
+				Rr:         tea.String(rr),
+				PageNumber: tea.Int64(pageNumber),
+				PageSize:   tea.Int64(100),
+			}
+
+			var err error
+			describeDNSSLBSubDomainsResponse, err = client.DescribeDNSSLBSubDomainsWithOptions(describeDNSSLBSubDomainsRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(describeDNSSLBSubDomains, reconnectBackoff); err != nil {
+			return false, err
+		}
+
+		if describeDNSSLBSubDomainsResponse.Body.SlbSubDomains == nil ||
+			len(describeDNSSLBSubDomainsResponse.Body.SlbSubDomains.SlbSubDomain) == 0 {
+			return false, nil
+		}
+		for _, subDomain := range describeDNSSLBSubDomainsResponse.Body.SlbSubDomains.SlbSubDomain {
+			if tea.StringValue(subDomain.SubDomain) == subDomainName && tea.StringValue(subDomain.Type) == recordType {
+				return tea.BoolValue(subDomain.Open), nil
+			}
+		}
+		if pageNumber*100 >= tea.Int64Value(describeDNSSLBSubDomainsResponse.Body.TotalCount) {
+			return false, nil
+		}
+	}
+}
+
+// setAliDnsSLBStatus enables or disables weighted round robin for the
+// subdomain with the record type, if it is not already in that status.
+func setAliDnsSLBStatus(client *alicloudDnsClient.Client, domainName, rr, recordType string, open bool) error {
+	currentlyOpen, err := getAliDnsSLBStatus(client, domainName, rr, recordType)
+	if err != nil {
+		return err
+	}
+	if currentlyOpen == open {
+		return nil
+	}
+
+	setDNSSLBStatus := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		setDNSSLBStatusRequest := &alicloudDnsClient.SetDNSSLBStatusRequest{
+			DomainName: tea.String(domainName),
+			SubDomain:  tea.String(getAliDnsSubDomainName(domainName, rr)),
+			Type:       tea.String(recordType),
+			Open:       tea.Bool(open),
+		}
+
+		if _, err := client.SetDNSSLBStatusWithOptions(setDNSSLBStatusRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setDNSSLBStatus, reconnectBackoff)
+}
+
+// updateAliDnsSLBWeight sets the weight of the record in the weighted round
+// robin of its subdomain.
+func updateAliDnsSLBWeight(client *alicloudDnsClient.Client, recordId string, weight int64) error {
+	updateDNSSLBWeight := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateDNSSLBWeightRequest := &alicloudDnsClient.UpdateDNSSLBWeightRequest{
+			RecordId: tea.String(recordId),
+			Weight:   tea.Int32(int32(weight)),
+		}
+
+		if _, err := client.UpdateDNSSLBWeightWithOptions(updateDNSSLBWeightRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateDNSSLBWeight, reconnectBackoff)
+}
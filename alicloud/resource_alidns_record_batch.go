@@ -0,0 +1,796 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+const (
+	// aliDnsBatchMaxRecords is the maximum number of records of a batch task.
+	aliDnsBatchMaxRecords = 1000
+	// aliDnsBatchTimeout is the maximum time to wait for a batch task.
+	aliDnsBatchTimeout = 10 * time.Minute
+)
+
+var (
+	_ resource.Resource                = &aliDnsRecordBatchResource{}
+	_ resource.ResourceWithConfigure   = &aliDnsRecordBatchResource{}
+	_ resource.ResourceWithImportState = &aliDnsRecordBatchResource{}
+	_ resource.ResourceWithModifyPlan  = &aliDnsRecordBatchResource{}
+)
+
+// aliDnsBatchRecordType is the type of the elements of records and
+// applied_records.
+var aliDnsBatchRecordType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"rr":       types.StringType,
+		"type":     types.StringType,
+		"value":    types.StringType,
+		"ttl":      types.Int64Type,
+		"line":     types.StringType,
+		"priority": types.Int64Type,
+	},
+}
+
+func NewAliDnsRecordBatchResource() resource.Resource {
+	return &aliDnsRecordBatchResource{}
+}
+
+type aliDnsRecordBatchResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsRecordBatchResourceModel struct {
+	DomainName     types.String         `tfsdk:"domain_name"`
+	Records        []*aliDnsBatchRecord `tfsdk:"records"`
+	AppliedRecords types.Set            `tfsdk:"applied_records"`
+}
+
+type aliDnsBatchRecord struct {
+	RR       types.String `tfsdk:"rr"`
+	Type     types.String `tfsdk:"type"`
+	Value    types.String `tfsdk:"value"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Line     types.String `tfsdk:"line"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+// Metadata returns the resource DNS record batch type name.
+func (r *aliDnsRecordBatchResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_record_batch"
+}
+
+// Schema defines the schema for the DNS record batch resource.
+func (r *aliDnsRecordBatchResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alidns record batch resource, which manages a set of records of a domain " +
+			"through the asynchronous batch operation API.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "Domain Name of the records.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				Description: "The records of the domain managed by the batch.",
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"rr": schema.StringAttribute{
+							Description: "Host Record (RR) of the record.",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Record Type. Valid values: A, AAAA, CNAME, MX, TXT, NS, SRV, CAA, " +
+								"REDIRECT_URL, FORWARD_URL.",
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("A", "AAAA", "CNAME", "MX", "TXT", "NS", "SRV", "CAA",
+									"REDIRECT_URL", "FORWARD_URL"),
+							},
+						},
+						"value": schema.StringAttribute{
+							Description: "Record Value.",
+							Required:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Time to live of the record in seconds. Default to 600.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"line": schema.StringAttribute{
+							Description: "Resolution Line of the record. Default to default.",
+							Optional:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Priority of the MX record.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 50),
+							},
+						},
+					},
+				},
+			},
+			"applied_records": schema.SetNestedAttribute{
+				Description: "The records applied to the domain. The records which failed to be applied, " +
+					"or were changed outside of Terraform, are missing from it and are applied again on " +
+					"the next apply.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"rr": schema.StringAttribute{
+							Description: "Host Record (RR) of the record.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Record Type.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "Record Value.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Time to live of the record in seconds.",
+							Computed:    true,
+						},
+						"line": schema.StringAttribute{
+							Description: "Resolution Line of the record.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Priority of the MX record.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aliDnsRecordBatchResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+// Create a new DNS record batch resource
+func (r *aliDnsRecordBatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan *aliDnsRecordBatchResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applied := &aliDnsRecordBatchResourceModel{
+		DomainName: plan.DomainName,
+		Records:    []*aliDnsBatchRecord{},
+	}
+	diags, recordFailures := r.applyRecords(ctx, applied, plan)
+	resp.Diagnostics.Append(diags...)
+
+	// An error in Create taints the resource, which replaces the whole batch
+	// on the next apply. The failed records are reported as warnings instead,
+	// and are left out of applied_records, so only they are applied again on
+	// the next apply.
+	for _, recordFailure := range recordFailures {
+		detail := recordFailure.Detail() + "\n\nThe record is applied again on the next apply."
+		if withPath, ok := recordFailure.(diag.DiagnosticWithPath); ok {
+			resp.Diagnostics.AddAttributeWarning(withPath.Path(), recordFailure.Summary(), detail)
+		} else {
+			resp.Diagnostics.AddWarning(recordFailure.Summary(), detail)
+		}
+	}
+
+	// Set state to the planned records, with the records applied
+	plan.setAppliedRecords(applied.Records)
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read DNS record batch resource information
+func (r *aliDnsRecordBatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state *aliDnsRecordBatchResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	currentRecords, err := r.describeBatchRecords(state.DomainName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain Records",
+			err.Error(),
+		)
+		return
+	}
+
+	// All the records of the domain are adopted on import.
+	if state.Records == nil {
+		state.Records = make([]*aliDnsBatchRecord, 0, len(currentRecords))
+		for _, record := range currentRecords {
+			state.Records = append(state.Records, record)
+		}
+		state.setAppliedRecords(state.Records)
+	} else {
+		// Only the applied records are refreshed, records is kept as
+		// configured so the drift is planned against applied_records.
+		appliedRecords, diags := state.appliedRecords(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		records := make([]*aliDnsBatchRecord, 0, len(appliedRecords))
+		for _, appliedRecord := range appliedRecords {
+			currentRecord, ok := currentRecords[appliedRecord.key()]
+			if !ok {
+				continue
+			}
+
+			// Omitted attributes are kept null unless they have drifted.
+			record := *appliedRecord
+			if !record.TTL.IsNull() || currentRecord.TTL.ValueInt64() != defaultAliDnsZoneTTL {
+				record.TTL = currentRecord.TTL
+			}
+			if !record.Priority.IsNull() {
+				record.Priority = currentRecord.Priority
+			}
+			records = append(records, &record)
+		}
+		state.setAppliedRecords(records)
+	}
+
+	// Set refreshed state
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the DNS record batch resource and sets the updated Terraform state on success.
+func (r *aliDnsRecordBatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *aliDnsRecordBatchResourceModel
+
+	// Retrieve values from plan
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appliedRecords, diags := state.appliedRecords(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applied := &aliDnsRecordBatchResourceModel{
+		DomainName: state.DomainName,
+		Records:    appliedRecords,
+	}
+	diags, recordFailures := r.applyRecords(ctx, applied, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(recordFailures...)
+
+	// Set state to the planned records, the failed records are left out of
+	// applied_records so they are applied again on the next apply.
+	plan.setAppliedRecords(applied.Records)
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the DNS record batch resource and removes the Terraform state on success.
+func (r *aliDnsRecordBatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state *aliDnsRecordBatchResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appliedRecords, diags := state.appliedRecords(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applied := &aliDnsRecordBatchResourceModel{
+		DomainName: state.DomainName,
+		Records:    appliedRecords,
+	}
+	plan := &aliDnsRecordBatchResourceModel{
+		DomainName: state.DomainName,
+		Records:    []*aliDnsBatchRecord{},
+	}
+	diags, recordFailures := r.applyRecords(ctx, applied, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(recordFailures...)
+}
+
+func (r *aliDnsRecordBatchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import domain name and save to domain_name attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+}
+
+// ModifyPlan plans to apply the records again when the applied records differ
+// from the planned records, which happens when some records failed to be
+// applied or were changed outside of Terraform.
+func (r *aliDnsRecordBatchResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compare on create, and on destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var records, appliedRecords types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("records"), &records)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("applied_records"), &appliedRecords)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if appliedRecords.IsNull() || records.Equal(appliedRecords) {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("applied_records"), types.SetUnknown(aliDnsBatchRecordType))...)
+}
+
+// applyRecords makes the records of the domain match the planned records,
+// with the minimal set of records to delete and to add computed against the
+// current records. A record is deleted and added again if its TTL or priority
+// changed. The applied records are updated with the records applied, and
+// are the records managed by the batch. The errors of the
+// individual records which failed are returned in recordFailures, separately
+// from the errors which fail the whole apply.
+func (r *aliDnsRecordBatchResource) applyRecords(ctx context.Context, applied, plan *aliDnsRecordBatchResourceModel) (diags, recordFailures diag.Diagnostics) {
+	currentRecords, err := r.describeBatchRecords(plan.DomainName.ValueString())
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe Domain Records",
+			err.Error(),
+		)
+		return
+	}
+
+	plannedRecords := make(map[string]*aliDnsBatchRecord)
+	for _, record := range plan.Records {
+		plannedRecords[record.key()] = record
+	}
+
+	// Records to delete are the managed records which are no longer planned
+	// or have changed. Unmanaged records are never deleted.
+	appliedRecords := make(map[string]*aliDnsBatchRecord)
+	recordsToDelete := make([]*aliDnsBatchRecord, 0)
+	for _, record := range applied.Records {
+		key := record.key()
+		currentRecord, ok := currentRecords[key]
+		if !ok {
+			continue
+		}
+		if plannedRecord, ok := plannedRecords[key]; ok && plannedRecord.matches(currentRecord) {
+			appliedRecords[key] = plannedRecord
+			continue
+		}
+		recordsToDelete = append(recordsToDelete, currentRecord)
+	}
+
+	// Records to add are the planned records which do not exist, or are being
+	// deleted.
+	recordsToAdd := make([]*aliDnsBatchRecord, 0)
+	for _, record := range plan.Records {
+		key := record.key()
+		if _, ok := appliedRecords[key]; ok {
+			continue
+		}
+		if currentRecord, ok := currentRecords[key]; ok && record.matches(currentRecord) {
+			// The record exists but was not managed, it is adopted.
+			appliedRecords[key] = record
+			continue
+		}
+		if currentRecord, ok := currentRecords[key]; ok && !applied.manages(key) {
+			recordFailures.AddAttributeError(
+				path.Root("records"),
+				"[ERROR] Record Already Exists",
+				fmt.Sprintf("%s record %s of value %s exists with a different TTL or priority, and is not "+
+					"managed by the batch: TTL %d, priority %d.",
+					record.Type.ValueString(), record.RR.ValueString(), record.Value.ValueString(),
+					currentRecord.TTL.ValueInt64(), currentRecord.Priority.ValueInt64()),
+			)
+			continue
+		}
+		recordsToAdd = append(recordsToAdd, record)
+	}
+
+	setAppliedRecords := func() {
+		applied.Records = make([]*aliDnsBatchRecord, 0, len(appliedRecords))
+		for _, record := range plan.Records {
+			if appliedRecord, ok := appliedRecords[record.key()]; ok {
+				applied.Records = append(applied.Records, appliedRecord)
+			}
+		}
+	}
+
+	// The records are deleted first, so that the changed records can be
+	// added again.
+	if len(recordsToDelete) > 0 {
+		failures, err := r.operateBatch(ctx, "RR_DEL", plan.DomainName.ValueString(), recordsToDelete)
+		if err != nil {
+			diags.AddError(
+				"[API ERROR] Failed to Delete DNS Records",
+				err.Error(),
+			)
+			return
+		}
+		for _, record := range recordsToDelete {
+			reason, ok := failures[record.key()]
+			if !ok {
+				continue
+			}
+			// The record failed to be deleted is still managed.
+			if managedRecord := applied.find(record.key()); managedRecord != nil {
+				appliedRecords[record.key()] = managedRecord
+			}
+			recordFailures.AddError(
+				"[API ERROR] Failed to Delete DNS Record",
+				fmt.Sprintf("%s record %s of value %s on line %s: %s", record.Type.ValueString(),
+					record.RR.ValueString(), record.Value.ValueString(), record.Line.ValueString(), reason),
+			)
+		}
+	}
+
+	// Keep the managed records which failed to be deleted, but are no longer
+	// planned.
+	keptRecords := make([]*aliDnsBatchRecord, 0)
+	for key, record := range appliedRecords {
+		if _, ok := plannedRecords[key]; !ok {
+			keptRecords = append(keptRecords, record)
+		}
+	}
+
+	if len(recordsToAdd) > 0 {
+		failures, err := r.operateBatch(ctx, "RR_ADD", plan.DomainName.ValueString(), recordsToAdd)
+		if err != nil {
+			diags.AddError(
+				"[API ERROR] Failed to Add DNS Records",
+				err.Error(),
+			)
+			setAppliedRecords()
+			applied.Records = append(applied.Records, keptRecords...)
+			return
+		}
+		for _, record := range recordsToAdd {
+			reason, ok := failures[record.key()]
+			if !ok {
+				appliedRecords[record.key()] = record
+				continue
+			}
+			recordFailures.Append(diag.NewAttributeErrorDiagnostic(
+				path.Root("records").AtSetValue(record.objectValue()),
+				"[API ERROR] Failed to Add DNS Record",
+				fmt.Sprintf("%s record %s of value %s: %s", record.Type.ValueString(),
+					record.RR.ValueString(), record.Value.ValueString(), reason),
+			))
+		}
+	}
+
+	setAppliedRecords()
+	applied.Records = append(applied.Records, keptRecords...)
+	return
+}
+
+// operateBatch runs the batch task on the records and waits for it to
+// complete. It returns the reasons of the failed records keyed by record.
+func (r *aliDnsRecordBatchResource) operateBatch(ctx context.Context, batchType, domainName string, records []*aliDnsBatchRecord) (map[string]string, error) {
+	failures := make(map[string]string)
+	for start := 0; start < len(records); start += aliDnsBatchMaxRecords {
+		end := start + aliDnsBatchMaxRecords
+		if end > len(records) {
+			end = len(records)
+		}
+
+		operateBatchDomainRequest := &alicloudDnsClient.OperateBatchDomainRequest{
+			Type: tea.String(batchType),
+		}
+		for _, record := range records[start:end] {
+			recordInfo := &alicloudDnsClient.OperateBatchDomainRequestDomainRecordInfo{
+				Domain: tea.String(domainName),
+				Rr:     tea.String(record.RR.ValueString()),
+				Type:   tea.String(record.Type.ValueString()),
+				Value:  tea.String(record.Value.ValueString()),
+				Line:   tea.String(record.line()),
+				Ttl:    tea.Int32(int32(record.ttl())),
+			}
+			if !record.Priority.IsNull() {
+				recordInfo.Priority = tea.Int32(int32(record.Priority.ValueInt64()))
+			}
+			operateBatchDomainRequest.DomainRecordInfo = append(operateBatchDomainRequest.DomainRecordInfo, recordInfo)
+		}
+
+		var taskId *int64
+		operateBatchDomain := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			operateBatchDomainResponse, err := r.client.OperateBatchDomainWithOptions(operateBatchDomainRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			taskId = operateBatchDomainResponse.Body.TaskId
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(operateBatchDomain, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		failedCount, err := r.waitForBatchTask(ctx, batchType, taskId)
+		if err != nil {
+			return nil, err
+		}
+		if failedCount == 0 {
+			continue
+		}
+
+		taskFailures, err := r.describeBatchFailures(batchType, taskId)
+		if err != nil {
+			return nil, err
+		}
+		for key, reason := range taskFailures {
+			failures[key] = reason
+		}
+	}
+	return failures, nil
+}
+
+// waitForBatchTask polls the result of the batch task until it completes,
+// and returns the number of failed records.
+func (r *aliDnsRecordBatchResource) waitForBatchTask(ctx context.Context, batchType string, taskId *int64) (int32, error) {
+	describeBatchResultCountRequest := &alicloudDnsClient.DescribeBatchResultCountRequest{
+		BatchType: tea.String(batchType),
+		TaskId:    taskId,
+	}
+
+	var failedCount int32
+	describeBatchResultCount := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		describeBatchResultCountResponse, err := r.client.DescribeBatchResultCountWithOptions(describeBatchResultCountRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		// Status of the task: -1 for no task, 0 for in progress, 1 for completed.
+		switch tea.Int32Value(describeBatchResultCountResponse.Body.Status) {
+		case -1:
+			return backoff.Permanent(fmt.Errorf("batch task %d not found", tea.Int64Value(taskId)))
+		case 0:
+			return fmt.Errorf("batch task %d is in progress", tea.Int64Value(taskId))
+		}
+		failedCount = tea.Int32Value(describeBatchResultCountResponse.Body.FailedCount)
+		return nil
+	}
+
+	pollBackoff := backoff.NewExponentialBackOff()
+	pollBackoff.MaxInterval = 10 * time.Second
+	pollBackoff.MaxElapsedTime = aliDnsBatchTimeout
+	if err := backoff.Retry(describeBatchResultCount, backoff.WithContext(pollBackoff, ctx)); err != nil {
+		return 0, err
+	}
+	return failedCount, nil
+}
+
+// describeBatchFailures returns the reasons of the failed records of the
+// batch task keyed by record.
+func (r *aliDnsRecordBatchResource) describeBatchFailures(batchType string, taskId *int64) (map[string]string, error) {
+	failures := make(map[string]string)
+	describeBatchResultDetailRequest := &alicloudDnsClient.DescribeBatchResultDetailRequest{
+		BatchType: tea.String(batchType),
+		TaskId:    taskId,
+		Status:    tea.String("FAIL"),
+		PageSize:  tea.Int32(100),
+	}
+
+	for pageNumber := int32(1); ; pageNumber++ {
+		describeBatchResultDetailRequest.PageNumber = tea.Int32(pageNumber)
+
+		var describeBatchResultDetailResponse *alicloudDnsClient.DescribeBatchResultDetailResponse
+		describeBatchResultDetail := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			var err error
+			describeBatchResultDetailResponse, err = r.client.DescribeBatchResultDetailWithOptions(describeBatchResultDetailRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(describeBatchResultDetail, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		body := describeBatchResultDetailResponse.Body
+		if body.BatchResultDetails == nil || len(body.BatchResultDetails.BatchResultDetail) == 0 {
+			break
+		}
+		for _, detail := range body.BatchResultDetails.BatchResultDetail {
+			record := &aliDnsBatchRecord{
+				RR:    types.StringValue(tea.StringValue(detail.Rr)),
+				Type:  types.StringValue(tea.StringValue(detail.Type)),
+				Value: types.StringValue(tea.StringValue(detail.Value)),
+				Line:  types.StringValue(tea.StringValue(detail.Line)),
+			}
+			failures[record.key()] = tea.StringValue(detail.Reason)
+		}
+		if int64(pageNumber)*100 >= tea.Int64Value(body.TotalCount) {
+			break
+		}
+	}
+	return failures, nil
+}
+
+// describeBatchRecords returns all the records of the domain keyed by record.
+func (r *aliDnsRecordBatchResource) describeBatchRecords(domainName string) (map[string]*aliDnsBatchRecord, error) {
+	records, err := describeAliDnsDomainRecords(r.client, &alicloudDnsClient.DescribeDomainRecordsRequest{
+		DomainName: tea.String(domainName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	batchRecords := make(map[string]*aliDnsBatchRecord)
+	for _, record := range records {
+		batchRecord := &aliDnsBatchRecord{
+			RR:       types.StringValue(tea.StringValue(record.RR)),
+			Type:     types.StringValue(tea.StringValue(record.Type)),
+			Value:    types.StringValue(tea.StringValue(record.Value)),
+			TTL:      types.Int64Value(tea.Int64Value(record.TTL)),
+			Line:     types.StringValue(tea.StringValue(record.Line)),
+			Priority: types.Int64Null(),
+		}
+		if record.Priority != nil {
+			batchRecord.Priority = types.Int64Value(tea.Int64Value(record.Priority))
+		}
+		batchRecords[batchRecord.key()] = batchRecord
+	}
+	return batchRecords, nil
+}
+
+// appliedRecords returns the records applied to the domain. All the records
+// are applied for a state saved before applied_records was added.
+func (m *aliDnsRecordBatchResourceModel) appliedRecords(ctx context.Context) ([]*aliDnsBatchRecord, diag.Diagnostics) {
+	if m.AppliedRecords.IsNull() || m.AppliedRecords.IsUnknown() {
+		return m.Records, nil
+	}
+
+	records := make([]*aliDnsBatchRecord, 0, len(m.AppliedRecords.Elements()))
+	diags := m.AppliedRecords.ElementsAs(ctx, &records, false)
+	return records, diags
+}
+
+// setAppliedRecords sets applied_records to the records.
+func (m *aliDnsRecordBatchResourceModel) setAppliedRecords(records []*aliDnsBatchRecord) {
+	elements := make([]attr.Value, 0, len(records))
+	for _, record := range records {
+		elements = append(elements, record.objectValue())
+	}
+	m.AppliedRecords = types.SetValueMust(aliDnsBatchRecordType, elements)
+}
+
+// manages returns whether the record is managed by the batch.
+func (m *aliDnsRecordBatchResourceModel) manages(key string) bool {
+	return m.find(key) != nil
+}
+
+// find returns the managed record of the key.
+func (m *aliDnsRecordBatchResourceModel) find(key string) *aliDnsBatchRecord {
+	for _, record := range m.Records {
+		if record.key() == key {
+			return record
+		}
+	}
+	return nil
+}
+
+// key identifies the record regardless of its TTL and priority.
+func (b *aliDnsBatchRecord) key() string {
+	return strings.Join([]string{
+		strings.ToLower(b.RR.ValueString()),
+		b.Type.ValueString(),
+		b.Value.ValueString(),
+		b.line(),
+	}, "|")
+}
+
+func (b *aliDnsBatchRecord) line() string {
+	if b.Line.IsNull() || b.Line.ValueString() == "" {
+		return "default"
+	}
+	return b.Line.ValueString()
+}
+
+func (b *aliDnsBatchRecord) ttl() int64 {
+	if b.TTL.IsNull() {
+		return defaultAliDnsZoneTTL
+	}
+	return b.TTL.ValueInt64()
+}
+
+// matches returns whether the current record has the TTL and priority of
+// the record. An omitted priority matches any priority.
+func (b *aliDnsBatchRecord) matches(currentRecord *aliDnsBatchRecord) bool {
+	if b.ttl() != currentRecord.ttl() {
+		return false
+	}
+	return b.Priority.IsNull() || b.Priority.ValueInt64() == currentRecord.Priority.ValueInt64()
+}
+
+// objectValue converts the record into the value of a records element.
+func (b *aliDnsBatchRecord) objectValue() types.Object {
+	return types.ObjectValueMust(
+		aliDnsBatchRecordType.AttrTypes,
+		map[string]attr.Value{
+			"rr":       b.RR,
+			"type":     b.Type,
+			"value":    b.Value,
+			"ttl":      b.TTL,
+			"line":     b.Line,
+			"priority": b.Priority,
+		},
+	)
+}
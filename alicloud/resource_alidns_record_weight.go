@@ -2,10 +2,13 @@ package alicloud
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 
 	util "github.com/alibabacloud-go/tea-utils/v2/service"
 	"github.com/alibabacloud-go/tea/tea"
@@ -14,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
@@ -26,6 +30,10 @@ var (
 	_ resource.ResourceWithModifyPlan  = &aliDnsRecordWeightResource{}
 )
 
+// errAliDnsRecordNotFound is returned when the record of the weight does not
+// exist, in which case the resource is removed from state.
+var errAliDnsRecordNotFound = errors.New("domain record not found")
+
 func NewAliDnsRecordWeightResource() resource.Resource {
 	return &aliDnsRecordWeightResource{}
 }
@@ -35,9 +43,13 @@ type aliDnsRecordWeightResource struct {
 }
 
 type aliDnsRecordWeightResourceModel struct {
-	Id     types.String `tfsdk:"id"`
-	Weight types.Int64  `tfsdk:"weight"`
-	Status types.Bool   `tfsdk:"status"`
+	Id         types.String `tfsdk:"id"`
+	DomainName types.String `tfsdk:"domain_name"`
+	RR         types.String `tfsdk:"rr"`
+	Type       types.String `tfsdk:"type"`
+	Value      types.String `tfsdk:"value"`
+	Weight     types.Int64  `tfsdk:"weight"`
+	Status     types.Bool   `tfsdk:"status"`
 }
 
 // Metadata returns the resource DNS weight type name.
@@ -51,9 +63,66 @@ func (r *aliDnsRecordWeightResource) Schema(_ context.Context, _ resource.Schema
 		Description: "Provides a Alidns record weight resource.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Subdomain Record Id.",
-				Required:    true,
+				Description: "Subdomain Record Id. Either id, or domain_name, rr, type and value must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("domain_name")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description: "Domain Name of the record.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(
+						path.MatchRoot("rr"),
+						path.MatchRoot("type"),
+						path.MatchRoot("value"),
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rr": schema.StringAttribute{
+				Description: "Host Record (RR) of the record.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("domain_name")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Record Type.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("domain_name")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "Record Value.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("domain_name")),
+				},
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -87,6 +156,15 @@ func (r *aliDnsRecordWeightResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	// Look for the record of the domain name, RR, type and value
+	if err := r.resolveRecord(plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Find DNS Record",
+			err.Error(),
+		)
+		return
+	}
+
 	// Set Weight of SubDomain
 	err := r.setWeight(plan)
 	if err != nil {
@@ -100,6 +178,10 @@ func (r *aliDnsRecordWeightResource) Create(ctx context.Context, req resource.Cr
 	// Set state items
 	state := &aliDnsRecordWeightResourceModel{}
 	state.Id = plan.Id
+	state.DomainName = plan.DomainName
+	state.RR = plan.RR
+	state.Type = plan.Type
+	state.Value = plan.Value
 	state.Weight = plan.Weight
 	state.Status = plan.Status
 
@@ -121,6 +203,22 @@ func (r *aliDnsRecordWeightResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
+	// The record Id is only missing when imported by domain name, RR, type
+	// and value.
+	if state.Id.IsNull() {
+		if err := r.resolveRecord(state); err != nil {
+			if errors.Is(err, errAliDnsRecordNotFound) {
+				resp.State.RemoveResource(ctx)
+			} else {
+				resp.Diagnostics.AddError(
+					"[API ERROR] Failed to Find DNS Record",
+					err.Error(),
+				)
+			}
+			return
+		}
+	}
+
 	// Retry backoff function
 	readRecordWeight := func() error {
 		runtime := &util.RuntimeOptions{}
@@ -137,7 +235,7 @@ func (r *aliDnsRecordWeightResource) Read(ctx context.Context, req resource.Read
 					return err
 				} else {
 					if *_t.Code == "InvalidRR.NoExist" {
-						return backoff.Permanent(fmt.Errorf("domain record not found"))
+						return backoff.Permanent(errAliDnsRecordNotFound)
 					}
 					return backoff.Permanent(err)
 				}
@@ -146,9 +244,13 @@ func (r *aliDnsRecordWeightResource) Read(ctx context.Context, req resource.Read
 			}
 		}
 
+		state.DomainName = types.StringValue(tea.StringValue(responseById.Body.DomainName))
+		state.RR = types.StringValue(tea.StringValue(responseById.Body.RR))
+		state.Type = types.StringValue(tea.StringValue(responseById.Body.Type))
+		state.Value = types.StringValue(tea.StringValue(responseById.Body.Value))
+
 		// Combine Domain Name and Resource Record (RR) for SubDomain Name
 		subdomainName := fmt.Sprintf("%s.%s", *responseById.Body.RR, *responseById.Body.DomainName)
-		fmt.Println(subdomainName)
 
 		// Look for SubDomain Weight
 		DescSubDomainRecords := &alicloudDnsClient.DescribeSubDomainRecordsRequest{
@@ -211,7 +313,7 @@ func (r *aliDnsRecordWeightResource) Read(ctx context.Context, req resource.Read
 
 	err := backoff.Retry(readRecordWeight, reconnectBackoff)
 	if err != nil {
-		if err.Error() == "domain record not found" {
+		if errors.Is(err, errAliDnsRecordNotFound) {
 			resp.State.RemoveResource(ctx)
 		} else {
 			resp.Diagnostics.AddError(
@@ -254,6 +356,10 @@ func (r *aliDnsRecordWeightResource) Update(ctx context.Context, req resource.Up
 	// Set state values
 	state := &aliDnsRecordWeightResourceModel{}
 	state.Id = plan.Id
+	state.DomainName = plan.DomainName
+	state.RR = plan.RR
+	state.Type = plan.Type
+	state.Value = plan.Value
 	state.Weight = plan.Weight
 	state.Status = plan.Status
 
@@ -277,6 +383,15 @@ func (r *aliDnsRecordWeightResource) Delete(ctx context.Context, req resource.De
 }
 
 func (r *aliDnsRecordWeightResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by <domain_name>/<rr>/<type>/<value>, the value may contain slashes
+	if parts := strings.SplitN(req.ID, "/", 4); len(parts) == 4 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rr"), parts[1])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), parts[2])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("value"), parts[3])...)
+		return
+	}
+
 	// Retrieve import RecordId and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -301,6 +416,27 @@ func (r *aliDnsRecordWeightResource) ModifyPlan(ctx context.Context, req resourc
 	}
 }
 
+// resolveRecord looks up the record Id by the domain name, RR, type and value
+// when the record Id is not known.
+func (r *aliDnsRecordWeightResource) resolveRecord(model *aliDnsRecordWeightResourceModel) error {
+	if !(model.Id.IsNull() || model.Id.IsUnknown()) {
+		return nil
+	}
+
+	records, err := describeAliDnsSubDomainRecords(r.client, model.DomainName.ValueString(), model.RR.ValueString(), model.Type.ValueString())
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if strings.EqualFold(tea.StringValue(record.Value), model.Value.ValueString()) {
+			model.Id = types.StringValue(tea.StringValue(record.RecordId))
+			return nil
+		}
+	}
+	return errAliDnsRecordNotFound
+}
+
 func (r *aliDnsRecordWeightResource) setWeight(plan *aliDnsRecordWeightResourceModel) error {
 	setRecordWeight := func() error {
 		runtime := &util.RuntimeOptions{}
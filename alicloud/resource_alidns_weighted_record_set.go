@@ -0,0 +1,445 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &aliDnsWeightedRecordSetResource{}
+	_ resource.ResourceWithConfigure   = &aliDnsWeightedRecordSetResource{}
+	_ resource.ResourceWithImportState = &aliDnsWeightedRecordSetResource{}
+	_ resource.ResourceWithModifyPlan  = &aliDnsWeightedRecordSetResource{}
+)
+
+func NewAliDnsWeightedRecordSetResource() resource.Resource {
+	return &aliDnsWeightedRecordSetResource{}
+}
+
+type aliDnsWeightedRecordSetResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsWeightedRecordSetResourceModel struct {
+	DomainName   types.String       `tfsdk:"domain_name"`
+	RR           types.String       `tfsdk:"rr"`
+	Type         types.String       `tfsdk:"type"`
+	Weights      types.Map          `tfsdk:"weights"`
+	GradualShift *gradualShiftModel `tfsdk:"gradual_shift"`
+	RecordIds    types.Map          `tfsdk:"record_ids"`
+}
+
+type gradualShiftModel struct {
+	Steps           types.Int64 `tfsdk:"steps"`
+	IntervalSeconds types.Int64 `tfsdk:"interval_seconds"`
+}
+
+// Metadata returns the resource DNS weighted record set type name.
+func (r *aliDnsWeightedRecordSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_weighted_record_set"
+}
+
+// Schema defines the schema for the DNS weighted record set resource.
+func (r *aliDnsWeightedRecordSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alidns weighted record set resource, which exclusively manages the weights " +
+			"of all the records of a subdomain and record type.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "Domain Name of the records.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rr": schema.StringAttribute{
+				Description: "Host Record (RR) of the records.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Record Type of the records.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"weights": schema.MapAttribute{
+				Description: "Weight of every record, keyed by record value. Every record of the subdomain " +
+					"and record type must be listed, and the values of the records must be unique. " +
+					"Valid values: 1 to 100.",
+				Required:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.ValueInt64sAre(int64validator.Between(1, 100)),
+				},
+			},
+			"gradual_shift": schema.SingleNestedAttribute{
+				Description: "Moves the weights to the new values in steps instead of at once.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"steps": schema.Int64Attribute{
+						Description: "Number of steps to move the weights in. Valid values: 1 to 100.",
+						Required:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 100),
+						},
+					},
+					"interval_seconds": schema.Int64Attribute{
+						Description: "Seconds to wait between the steps. Valid values: 0 to 3600.",
+						Required:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(0, 3600),
+						},
+					},
+				},
+			},
+			"record_ids": schema.MapAttribute{
+				Description: "Record Id of every record, keyed by record value.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aliDnsWeightedRecordSetResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+// Create a new DNS weighted record set resource
+func (r *aliDnsWeightedRecordSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan *aliDnsWeightedRecordSetResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Enable weighted round robin before any weight is updated
+	if err := setAliDnsSLBStatus(r.client, plan.DomainName.ValueString(), plan.RR.ValueString(), plan.Type.ValueString(), true); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Enable DNS SLB",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.shiftWeights(ctx, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Set DNS Record Weights",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read DNS weighted record set resource information
+func (r *aliDnsWeightedRecordSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state *aliDnsWeightedRecordSetResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := describeAliDnsSubDomainRecords(r.client, state.DomainName.ValueString(), state.RR.ValueString(), state.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read DNS Subdomain Records",
+			err.Error(),
+		)
+		return
+	}
+	if len(records) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	weights, recordIds := getAliDnsRecordWeights(records)
+	state.Weights = types.MapValueMust(types.Int64Type, weights)
+	state.RecordIds = types.MapValueMust(types.StringType, recordIds)
+
+	// Set refreshed state
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the DNS weighted record set resource and sets the updated Terraform state on success.
+func (r *aliDnsWeightedRecordSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan *aliDnsWeightedRecordSetResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Weighted round robin may have been disabled outside Terraform
+	if err := setAliDnsSLBStatus(r.client, plan.DomainName.ValueString(), plan.RR.ValueString(), plan.Type.ValueString(), true); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Enable DNS SLB",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.shiftWeights(ctx, plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Set DNS Record Weights",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state to plan data
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete disables the weighted round robin of the subdomain and removes the Terraform state on success.
+func (r *aliDnsWeightedRecordSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state *aliDnsWeightedRecordSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := setAliDnsSLBStatus(r.client, state.DomainName.ValueString(), state.RR.ValueString(), state.Type.ValueString(), false); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Disable DNS SLB",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *aliDnsWeightedRecordSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by <domain_name>/<rr>/<type>
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format of <domain_name>/<rr>/<type>, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rr"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), parts[2])...)
+}
+
+// ModifyPlan checks the planned weights against the records of the subdomain,
+// so the weights that cannot be applied are reported at plan time.
+func (r *aliDnsWeightedRecordSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan *aliDnsWeightedRecordSetResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.DomainName.IsUnknown() || plan.RR.IsUnknown() || plan.Type.IsUnknown() || plan.Weights.IsUnknown() {
+		return
+	}
+	for _, weight := range plan.Weights.Elements() {
+		if weight.IsUnknown() {
+			return
+		}
+	}
+
+	records, err := describeAliDnsSubDomainRecords(r.client, plan.DomainName.ValueString(), plan.RR.ValueString(), plan.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe DNS Records",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := checkAliDnsWeightedRecords(records, plan.Weights.Elements()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("weights"),
+			"[ERROR] Invalid Weights",
+			err.Error(),
+		)
+	}
+}
+
+// shiftWeights moves the weights of the records to the planned weights, in
+// the steps of gradual_shift if it is set, and sets record_ids of the plan.
+// In every step the increased weights are applied before the decreased ones,
+// so the total weight of the records never drops mid-apply.
+func (r *aliDnsWeightedRecordSetResource) shiftWeights(ctx context.Context, plan *aliDnsWeightedRecordSetResourceModel) error {
+	records, err := describeAliDnsSubDomainRecords(r.client, plan.DomainName.ValueString(), plan.RR.ValueString(), plan.Type.ValueString())
+	if err != nil {
+		return err
+	}
+
+	if err := checkAliDnsWeightedRecords(records, plan.Weights.Elements()); err != nil {
+		return err
+	}
+
+	// The weights of every step are interpolated from the starting weights,
+	// so the weights move in even steps.
+	startWeights, currentWeights := make(map[string]int64), make(map[string]int64)
+	recordIds := make(map[string]string)
+	for _, record := range records {
+		value := tea.StringValue(record.Value)
+		startWeights[value] = int64(tea.Int32Value(record.Weight))
+		currentWeights[value] = startWeights[value]
+		recordIds[value] = tea.StringValue(record.RecordId)
+	}
+
+	targetWeights := make(map[string]int64)
+	for value, weight := range plan.Weights.Elements() {
+		targetWeights[value] = weight.(types.Int64).ValueInt64()
+	}
+
+	steps, interval := int64(1), time.Duration(0)
+	if plan.GradualShift != nil {
+		steps = plan.GradualShift.Steps.ValueInt64()
+		interval = time.Duration(plan.GradualShift.IntervalSeconds.ValueInt64()) * time.Second
+	}
+
+	for step := int64(1); step <= steps; step++ {
+		stepWeights := make(map[string]int64)
+		for value, targetWeight := range targetWeights {
+			stepWeights[value] = startWeights[value] + (targetWeight-startWeights[value])*step/steps
+		}
+
+		values := make([]string, 0, len(stepWeights))
+		for value := range stepWeights {
+			if stepWeights[value] != currentWeights[value] {
+				values = append(values, value)
+			}
+		}
+		sort.Slice(values, func(i, j int) bool {
+			iIncreased := stepWeights[values[i]] > currentWeights[values[i]]
+			jIncreased := stepWeights[values[j]] > currentWeights[values[j]]
+			if iIncreased != jIncreased {
+				return iIncreased
+			}
+			return values[i] < values[j]
+		})
+
+		for _, value := range values {
+			if err := updateAliDnsSLBWeight(r.client, recordIds[value], stepWeights[value]); err != nil {
+				return err
+			}
+			currentWeights[value] = stepWeights[value]
+		}
+
+		if step < steps && len(values) > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+	}
+
+	// Every record is listed in weights, as checked above.
+	_, recordIdValues := getAliDnsRecordWeights(records)
+	plan.RecordIds = types.MapValueMust(types.StringType, recordIdValues)
+
+	return nil
+}
+
+// checkAliDnsWeightedRecords checks that every record of the subdomain is
+// listed in the weights and that every weight has a record. The records are
+// keyed by value, so records with the same value, e.g. on different lines,
+// are rejected.
+func checkAliDnsWeightedRecords(records []*alicloudDnsClient.DescribeSubDomainRecordsResponseBodyDomainRecordsRecord, weights map[string]attr.Value) error {
+	recordValues := make(map[string]bool)
+	duplicateValues, missingValues, unknownValues := make([]string, 0), make([]string, 0), make([]string, 0)
+	for _, record := range records {
+		value := tea.StringValue(record.Value)
+		if recordValues[value] {
+			duplicateValues = append(duplicateValues, value)
+		}
+		recordValues[value] = true
+		if _, ok := weights[value]; !ok {
+			missingValues = append(missingValues, value)
+		}
+	}
+	for value := range weights {
+		if !recordValues[value] {
+			unknownValues = append(unknownValues, value)
+		}
+	}
+	sort.Strings(duplicateValues)
+	sort.Strings(missingValues)
+	sort.Strings(unknownValues)
+
+	if len(duplicateValues) > 0 {
+		return fmt.Errorf("multiple records are found with the following values, the weights of such records cannot be managed by value: %s", strings.Join(duplicateValues, ", "))
+	}
+	if len(missingValues) > 0 {
+		return fmt.Errorf("the records with the following values are not listed in weights: %s", strings.Join(missingValues, ", "))
+	}
+	if len(unknownValues) > 0 {
+		return fmt.Errorf("no record is found with the following values in weights: %s", strings.Join(unknownValues, ", "))
+	}
+	return nil
+}
+
+// getAliDnsRecordWeights returns the weights and the record Ids of the
+// records, keyed by record value.
+func getAliDnsRecordWeights(records []*alicloudDnsClient.DescribeSubDomainRecordsResponseBodyDomainRecordsRecord) (weights, recordIds map[string]attr.Value) {
+	weights = make(map[string]attr.Value)
+	recordIds = make(map[string]attr.Value)
+	for _, record := range records {
+		value := tea.StringValue(record.Value)
+		weights[value] = types.Int64Value(int64(tea.Int32Value(record.Weight)))
+		recordIds[value] = types.StringValue(tea.StringValue(record.RecordId))
+	}
+	return weights, recordIds
+}
@@ -0,0 +1,883 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudDnsClient "github.com/alibabacloud-go/alidns-20150109/v4/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+// defaultAliDnsZoneTTL is the TTL of the records without TTL when the zone
+// file has no $TTL directive.
+const defaultAliDnsZoneTTL = 600
+
+var (
+	_ resource.Resource                = &aliDnsZoneResource{}
+	_ resource.ResourceWithConfigure   = &aliDnsZoneResource{}
+	_ resource.ResourceWithImportState = &aliDnsZoneResource{}
+	_ resource.ResourceWithModifyPlan  = &aliDnsZoneResource{}
+)
+
+func NewAliDnsZoneResource() resource.Resource {
+	return &aliDnsZoneResource{}
+}
+
+type aliDnsZoneResource struct {
+	client *alicloudDnsClient.Client
+}
+
+type aliDnsZoneResourceModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	ZoneFile   types.String `tfsdk:"zone_file"`
+	Exclusive  types.Bool   `tfsdk:"exclusive"`
+	Records    types.Set    `tfsdk:"records"`
+}
+
+// aliDnsZoneRecord is a record of a zone file, in the form of an Alidns
+// record. Priority is only set for MX records, and recordId is only set for
+// the records described from Alidns.
+type aliDnsZoneRecord struct {
+	RR       string
+	Type     string
+	Value    string
+	TTL      int64
+	Priority int64
+	recordId string
+}
+
+// Metadata returns the resource DNS zone type name.
+func (r *aliDnsZoneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alidns_zone"
+}
+
+// Schema defines the schema for the DNS zone resource.
+func (r *aliDnsZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alidns zone resource, which reconciles the records of a domain to match " +
+			"a BIND zone file.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "Domain Name of the zone.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"zone_file": schema.StringAttribute{
+				Description: "Content of the BIND zone file. A, AAAA, CNAME, MX, TXT, SRV, CAA and NS records, " +
+					"and the $TTL and $ORIGIN directives are supported. SOA records and NS records of the domain " +
+					"itself are ignored.",
+				Required: true,
+			},
+			"exclusive": schema.BoolAttribute{
+				Description: "Whether to delete the records of the domain that are not in the zone file. " +
+					"Otherwise only the records removed from the zone file are deleted. Default to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"records": schema.SetNestedAttribute{
+				Description: "The records managed by the zone.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"rr": schema.StringAttribute{
+							Description: "Host Record (RR) of the record.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Record Type.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "Record Value.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Time to live of the record in seconds.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Priority of the MX record.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *aliDnsZoneResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).dnsClient
+}
+
+// Create a new DNS zone resource
+func (r *aliDnsZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan *aliDnsZoneResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.reconcileRecords(plan, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Reconcile DNS Records",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state to fully populated data
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read DNS zone resource information
+func (r *aliDnsZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state *aliDnsZoneResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	currentRecords, err := r.describeZoneRecords(state.DomainName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe Domain Records",
+			err.Error(),
+		)
+		return
+	}
+
+	// The zone file is only missing right after import, in which case all
+	// the records of the domain are adopted.
+	if state.ZoneFile.IsNull() {
+		state.ZoneFile = types.StringValue(renderAliDnsZoneFile(state.DomainName.ValueString(), currentRecords))
+		state.Exclusive = types.BoolValue(false)
+		state.Records = getAliDnsZoneRecordsSet(currentRecords)
+	} else if state.Exclusive.ValueBool() {
+		state.Records = getAliDnsZoneRecordsSet(currentRecords)
+	} else {
+		// Only keep the records that are managed by the zone, so that a
+		// managed record removed outside Terraform is added back.
+		managedRecords := make(map[string]bool)
+		for _, record := range getAliDnsZoneRecordsFromSet(state.Records) {
+			managedRecords[record.key()] = true
+		}
+
+		records := make([]*aliDnsZoneRecord, 0)
+		for _, record := range currentRecords {
+			if managedRecords[record.key()] {
+				records = append(records, record)
+			}
+		}
+		state.Records = getAliDnsZoneRecordsSet(records)
+	}
+
+	// Set refreshed state
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the DNS zone resource and sets the updated Terraform state on success.
+func (r *aliDnsZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *aliDnsZoneResourceModel
+
+	// Retrieve values from plan
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.reconcileRecords(plan, getAliDnsZoneRecordsFromSet(state.Records)); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Reconcile DNS Records",
+			err.Error(),
+		)
+		return
+	}
+
+	// Set state to plan data
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the records managed by the DNS zone resource and removes the Terraform state on success.
+func (r *aliDnsZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state *aliDnsZoneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reconciling to an empty zone deletes all the managed records.
+	managedRecords := getAliDnsZoneRecordsFromSet(state.Records)
+	state.Records = getAliDnsZoneRecordsSet(nil)
+	state.Exclusive = types.BoolValue(false)
+	if err := r.reconcileRecords(state, managedRecords); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete DNS Records",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *aliDnsZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import domain name and save to domain_name attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+}
+
+// ModifyPlan parses the zone file during plan, so that the records to be
+// reconciled can be reviewed before apply.
+func (r *aliDnsZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *aliDnsZoneResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.DomainName.IsUnknown() || plan.ZoneFile.IsUnknown() {
+		return
+	}
+
+	records, err := parseAliDnsZoneFile(plan.DomainName.ValueString(), plan.ZoneFile.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("zone_file"),
+			"[ERROR] Invalid Zone File",
+			err.Error(),
+		)
+		return
+	}
+
+	// The NS records of the domain itself are managed by Alidns.
+	zoneRecords := make([]*aliDnsZoneRecord, 0)
+	for _, record := range records {
+		if record.Type == "NS" && record.RR == "@" {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("zone_file"),
+				"NS Record Ignored",
+				fmt.Sprintf("The NS record of the domain itself is managed by Alidns and is ignored: %s", record.Value),
+			)
+			continue
+		}
+		zoneRecords = append(zoneRecords, record)
+	}
+	plan.Records = getAliDnsZoneRecordsSet(zoneRecords)
+
+	setPlanDiags := resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(setPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// reconcileRecords makes the records of the domain match the planned records.
+// The records that are not planned are deleted if they were previously
+// managed, or if the zone is exclusive.
+func (r *aliDnsZoneResource) reconcileRecords(plan *aliDnsZoneResourceModel, previousRecords []*aliDnsZoneRecord) error {
+	domainName := plan.DomainName.ValueString()
+	currentRecords, err := r.describeZoneRecords(domainName)
+	if err != nil {
+		return err
+	}
+
+	plannedRecords := make(map[string]*aliDnsZoneRecord)
+	for _, record := range getAliDnsZoneRecordsFromSet(plan.Records) {
+		plannedRecords[record.key()] = record
+	}
+	managedRecords := make(map[string]bool)
+	for _, record := range previousRecords {
+		managedRecords[record.key()] = true
+	}
+
+	// The records are deleted first, so that a record can be replaced by a
+	// CNAME record of the same host.
+	existingRecords := make(map[string]bool)
+	for _, record := range currentRecords {
+		key := record.key()
+		if _, ok := plannedRecords[key]; ok {
+			existingRecords[key] = true
+			continue
+		}
+		if !(plan.Exclusive.ValueBool() || managedRecords[key]) {
+			continue
+		}
+
+		deleteDomainRecordRequest := &alicloudDnsClient.DeleteDomainRecordRequest{
+			RecordId: tea.String(record.recordId),
+		}
+		if err := r.callZoneApi(func(runtime *util.RuntimeOptions) error {
+			_, err := r.client.DeleteDomainRecordWithOptions(deleteDomainRecordRequest, runtime)
+			return err
+		}); err != nil && !isAliDnsRecordNotFoundError(err) {
+			return err
+		}
+	}
+
+	for _, record := range currentRecords {
+		plannedRecord, ok := plannedRecords[record.key()]
+		if !ok || (plannedRecord.TTL == record.TTL && plannedRecord.Priority == record.Priority) {
+			continue
+		}
+
+		updateDomainRecordRequest := &alicloudDnsClient.UpdateDomainRecordRequest{
+			RecordId: tea.String(record.recordId),
+			RR:       tea.String(plannedRecord.RR),
+			Type:     tea.String(plannedRecord.Type),
+			Value:    tea.String(plannedRecord.Value),
+			TTL:      tea.Int64(plannedRecord.TTL),
+		}
+		if plannedRecord.Type == "MX" {
+			updateDomainRecordRequest.Priority = tea.Int64(plannedRecord.Priority)
+		}
+		if err := r.callZoneApi(func(runtime *util.RuntimeOptions) error {
+			_, err := r.client.UpdateDomainRecordWithOptions(updateDomainRecordRequest, runtime)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0)
+	for key := range plannedRecords {
+		if !existingRecords[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		plannedRecord := plannedRecords[key]
+
+		addDomainRecordRequest := &alicloudDnsClient.AddDomainRecordRequest{
+			DomainName: tea.String(domainName),
+			RR:         tea.String(plannedRecord.RR),
+			Type:       tea.String(plannedRecord.Type),
+			Value:      tea.String(plannedRecord.Value),
+			TTL:        tea.Int64(plannedRecord.TTL),
+		}
+		if plannedRecord.Type == "MX" {
+			addDomainRecordRequest.Priority = tea.Int64(plannedRecord.Priority)
+		}
+		if err := r.callZoneApi(func(runtime *util.RuntimeOptions) error {
+			_, err := r.client.AddDomainRecordWithOptions(addDomainRecordRequest, runtime)
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to add %s record %s: %w", plannedRecord.Type, plannedRecord.RR, err)
+		}
+	}
+
+	return nil
+}
+
+// describeZoneRecords lists the records of the domain that can be expressed
+// in a zone file, which are the records of the supported types on the
+// default line, except the NS records of the domain itself.
+func (r *aliDnsZoneResource) describeZoneRecords(domainName string) ([]*aliDnsZoneRecord, error) {
+	records, err := describeAliDnsDomainRecords(r.client, &alicloudDnsClient.DescribeDomainRecordsRequest{
+		DomainName: tea.String(domainName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	zoneRecords := make([]*aliDnsZoneRecord, 0)
+	for _, record := range records {
+		zoneRecord, ok := getAliDnsZoneRecord(record)
+		if !ok || (zoneRecord.Type == "NS" && zoneRecord.RR == "@") {
+			continue
+		}
+		zoneRecords = append(zoneRecords, zoneRecord)
+	}
+	return zoneRecords, nil
+}
+
+// callZoneApi retries the API call with backoff.
+func (r *aliDnsZoneResource) callZoneApi(call func(runtime *util.RuntimeOptions) error) error {
+	callApi := func() error {
+		runtime := &util.RuntimeOptions{}
+		if err := call(runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(callApi, reconnectBackoff)
+}
+
+// key identifies the record regardless of its TTL and priority.
+func (z *aliDnsZoneRecord) key() string {
+	return strings.Join([]string{z.RR, z.Type, z.Value}, "|")
+}
+
+func aliDnsZoneRecordType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"rr":       types.StringType,
+			"type":     types.StringType,
+			"value":    types.StringType,
+			"ttl":      types.Int64Type,
+			"priority": types.Int64Type,
+		},
+	}
+}
+
+// getAliDnsZoneRecordsSet converts the records into the records attribute.
+func getAliDnsZoneRecordsSet(records []*aliDnsZoneRecord) types.Set {
+	elements := make([]attr.Value, 0, len(records))
+	for _, record := range records {
+		priority := types.Int64Null()
+		if record.Type == "MX" {
+			priority = types.Int64Value(record.Priority)
+		}
+		elements = append(elements, types.ObjectValueMust(
+			aliDnsZoneRecordType().AttrTypes,
+			map[string]attr.Value{
+				"rr":       types.StringValue(record.RR),
+				"type":     types.StringValue(record.Type),
+				"value":    types.StringValue(record.Value),
+				"ttl":      types.Int64Value(record.TTL),
+				"priority": priority,
+			},
+		))
+	}
+	return types.SetValueMust(aliDnsZoneRecordType(), elements)
+}
+
+// getAliDnsZoneRecordsFromSet converts the records attribute into records.
+func getAliDnsZoneRecordsFromSet(records types.Set) []*aliDnsZoneRecord {
+	zoneRecords := make([]*aliDnsZoneRecord, 0)
+	for _, element := range records.Elements() {
+		attributes := element.(types.Object).Attributes()
+		zoneRecords = append(zoneRecords, &aliDnsZoneRecord{
+			RR:       attributes["rr"].(types.String).ValueString(),
+			Type:     attributes["type"].(types.String).ValueString(),
+			Value:    attributes["value"].(types.String).ValueString(),
+			TTL:      attributes["ttl"].(types.Int64).ValueInt64(),
+			Priority: attributes["priority"].(types.Int64).ValueInt64(),
+		})
+	}
+	return zoneRecords
+}
+
+// parseAliDnsZoneFile parses the BIND zone file of the domain into records.
+// Names are converted into host records (RR) relative to the domain, and the
+// values are converted into the formats of Alidns: names without the trailing
+// dot, MX preferences into priorities, and TXT strings concatenated.
+func parseAliDnsZoneFile(domainName, content string) ([]*aliDnsZoneRecord, error) {
+	domainName = strings.ToLower(strings.TrimSuffix(domainName, "."))
+	origin := domainName
+	defaultTTL := int64(defaultAliDnsZoneTTL)
+	owner := ""
+
+	records := make([]*aliDnsZoneRecord, 0)
+	lines, err := splitZoneFileLines(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range lines {
+		tokens := line.tokens
+		if len(tokens) == 0 {
+			continue
+		}
+
+		// Directives
+		if !line.blankOwner && strings.HasPrefix(tokens[0].text, "$") {
+			directive := strings.ToUpper(tokens[0].text)
+			switch directive {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN requires exactly one domain name", line.number)
+				}
+				origin = getZoneFileName(tokens[1].text, origin)
+			case "$TTL":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $TTL requires exactly one TTL", line.number)
+				}
+				if defaultTTL, err = parseZoneFileTTL(tokens[1].text); err != nil {
+					return nil, fmt.Errorf("line %d: %w", line.number, err)
+				}
+			default:
+				return nil, fmt.Errorf("line %d: unsupported directive %s", line.number, directive)
+			}
+			continue
+		}
+
+		// The owner is omitted if the line starts with a blank, in which case
+		// the previous owner is used.
+		if !line.blankOwner {
+			if tokens[0].quoted || tokens[0].text == "" {
+				return nil, fmt.Errorf("line %d: invalid owner %q", line.number, tokens[0].text)
+			}
+			owner = getZoneFileName(tokens[0].text, origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: the first record must have an owner", line.number)
+		}
+
+		// The TTL and the class may be in any order before the type.
+		ttl := defaultTTL
+		recordType := ""
+		for len(tokens) > 0 && recordType == "" {
+			if tokens[0].quoted || tokens[0].text == "" {
+				return nil, fmt.Errorf("line %d: expected TTL, class or record type, got %q", line.number, tokens[0].text)
+			}
+			token := tokens[0].text
+			tokens = tokens[1:]
+			switch upperToken := strings.ToUpper(token); {
+			case upperToken == "IN":
+			case upperToken == "CH" || upperToken == "HS":
+				return nil, fmt.Errorf("line %d: unsupported class %s", line.number, upperToken)
+			case len(token) > 0 && unicode.IsDigit(rune(token[0])):
+				if ttl, err = parseZoneFileTTL(token); err != nil {
+					return nil, fmt.Errorf("line %d: %w", line.number, err)
+				}
+			default:
+				recordType = upperToken
+			}
+		}
+		if recordType == "" {
+			return nil, fmt.Errorf("line %d: missing record type", line.number)
+		}
+		if recordType == "SOA" {
+			continue
+		}
+
+		rr, err := getZoneFileRR(owner, domainName)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+
+		record := &aliDnsZoneRecord{
+			RR:   rr,
+			Type: recordType,
+			TTL:  ttl,
+		}
+		if record.Value, record.Priority, err = parseZoneFileRdata(recordType, tokens, origin); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+type zoneFileToken struct {
+	text   string
+	quoted bool
+}
+
+type zoneFileLine struct {
+	number     int
+	blankOwner bool
+	tokens     []zoneFileToken
+}
+
+// splitZoneFileLines removes the comments of the zone file, joins the lines
+// grouped by parentheses, and splits every line into tokens.
+func splitZoneFileLines(content string) ([]*zoneFileLine, error) {
+	lines := make([]*zoneFileLine, 0)
+	lineNumber := 1
+	line := &zoneFileLine{number: lineNumber, blankOwner: len(content) > 0 && (content[0] == ' ' || content[0] == '\t')}
+	token := &strings.Builder{}
+	hasToken, quoted, inQuotes, inComment, escaped := false, false, false, false, false
+	parentheses := 0
+
+	endToken := func() {
+		if hasToken {
+			line.tokens = append(line.tokens, zoneFileToken{text: token.String(), quoted: quoted})
+		}
+		token.Reset()
+		hasToken, quoted = false, false
+	}
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if c == '\n' {
+			lineNumber++
+		}
+
+		switch {
+		case inComment:
+			if c != '\n' {
+				continue
+			}
+			inComment = false
+		case escaped:
+			token.WriteByte(c)
+			escaped = false
+			continue
+		case c == '\\':
+			token.WriteByte(c)
+			hasToken, escaped = true, true
+			continue
+		case inQuotes:
+			if c == '"' {
+				inQuotes = false
+			} else {
+				token.WriteByte(c)
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inQuotes, hasToken, quoted = true, true, true
+		case ';':
+			endToken()
+			inComment = true
+		case '(':
+			endToken()
+			parentheses++
+		case ')':
+			endToken()
+			if parentheses == 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+			}
+			parentheses--
+		case ' ', '\t', '\r':
+			endToken()
+		case '\n':
+			endToken()
+			if parentheses == 0 {
+				lines = append(lines, line)
+				line = &zoneFileLine{
+					number:     lineNumber,
+					blankOwner: i+1 < len(content) && (content[i+1] == ' ' || content[i+1] == '\t'),
+				}
+			}
+		default:
+			token.WriteByte(c)
+			hasToken = true
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("line %d: unterminated quoted string", lineNumber)
+	}
+	if parentheses != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", lineNumber)
+	}
+	endToken()
+	lines = append(lines, line)
+
+	return lines, nil
+}
+
+var zoneFileTTLRegex = regexp.MustCompile(`^(?i)(?:([0-9]+)([smhdw]?))+$`)
+var zoneFileTTLPartRegex = regexp.MustCompile(`(?i)([0-9]+)([smhdw]?)`)
+
+// parseZoneFileTTL parses a TTL in seconds, or with the units of BIND such
+// as 1h30m.
+func parseZoneFileTTL(ttl string) (int64, error) {
+	if !zoneFileTTLRegex.MatchString(ttl) {
+		return 0, fmt.Errorf("invalid TTL %s", ttl)
+	}
+
+	seconds := int64(0)
+	for _, part := range zoneFileTTLPartRegex.FindAllStringSubmatch(ttl, -1) {
+		value, err := strconv.ParseInt(part[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid TTL %s", ttl)
+		}
+		switch strings.ToLower(part[2]) {
+		case "m":
+			value *= 60
+		case "h":
+			value *= 3600
+		case "d":
+			value *= 86400
+		case "w":
+			value *= 604800
+		}
+		seconds += value
+	}
+	return seconds, nil
+}
+
+// getZoneFileName converts a name of the zone file into a fully qualified
+// name without the trailing dot.
+func getZoneFileName(name, origin string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	default:
+		return name + "." + origin
+	}
+}
+
+// getZoneFileRR converts a fully qualified name into the host record (RR) of
+// the domain.
+func getZoneFileRR(name, domainName string) (string, error) {
+	if name == domainName {
+		return "@", nil
+	}
+	if strings.HasSuffix(name, "."+domainName) {
+		return strings.TrimSuffix(name, "."+domainName), nil
+	}
+	return "", fmt.Errorf("%s is not in the domain %s", name, domainName)
+}
+
+// parseZoneFileRdata converts the data of the record into the value and the
+// priority of an Alidns record.
+func parseZoneFileRdata(recordType string, tokens []zoneFileToken, origin string) (value string, priority int64, err error) {
+	expectTokens := func(count int) error {
+		if len(tokens) != count {
+			return fmt.Errorf("%s record requires %d values, got %d", recordType, count, len(tokens))
+		}
+		return nil
+	}
+
+	switch recordType {
+	case "A":
+		if err := expectTokens(1); err != nil {
+			return "", 0, err
+		}
+		if ip := net.ParseIP(tokens[0].text); ip == nil || ip.To4() == nil {
+			return "", 0, fmt.Errorf("invalid IPv4 address %s", tokens[0].text)
+		}
+		return tokens[0].text, 0, nil
+	case "AAAA":
+		if err := expectTokens(1); err != nil {
+			return "", 0, err
+		}
+		ip := net.ParseIP(tokens[0].text)
+		if ip == nil || ip.To4() != nil {
+			return "", 0, fmt.Errorf("invalid IPv6 address %s", tokens[0].text)
+		}
+		return ip.String(), 0, nil
+	case "CNAME", "NS":
+		if err := expectTokens(1); err != nil {
+			return "", 0, err
+		}
+		return getZoneFileName(tokens[0].text, origin), 0, nil
+	case "MX":
+		if err := expectTokens(2); err != nil {
+			return "", 0, err
+		}
+		priority, err := strconv.ParseInt(tokens[0].text, 10, 64)
+		if err != nil || priority < 1 || priority > 50 {
+			return "", 0, fmt.Errorf("MX preference must be between 1 and 50 in Alidns, got %s", tokens[0].text)
+		}
+		return getZoneFileName(tokens[1].text, origin), priority, nil
+	case "TXT":
+		if len(tokens) == 0 {
+			return "", 0, fmt.Errorf("TXT record requires at least 1 value")
+		}
+		// The character strings are concatenated, like resolvers do.
+		text := &strings.Builder{}
+		for _, token := range tokens {
+			text.WriteString(unescapeZoneFileString(token.text))
+		}
+		return text.String(), 0, nil
+	case "SRV":
+		if err := expectTokens(4); err != nil {
+			return "", 0, err
+		}
+		for _, token := range tokens[:3] {
+			if _, err := strconv.ParseUint(token.text, 10, 16); err != nil {
+				return "", 0, fmt.Errorf("invalid SRV priority, weight or port %s", token.text)
+			}
+		}
+		return fmt.Sprintf("%s %s %s %s", tokens[0].text, tokens[1].text, tokens[2].text, getZoneFileName(tokens[3].text, origin)), 0, nil
+	case "CAA":
+		if err := expectTokens(3); err != nil {
+			return "", 0, err
+		}
+		if _, err := strconv.ParseUint(tokens[0].text, 10, 8); err != nil {
+			return "", 0, fmt.Errorf("invalid CAA flags %s", tokens[0].text)
+		}
+		return fmt.Sprintf(`%s %s "%s"`, tokens[0].text, strings.ToLower(tokens[1].text), unescapeZoneFileString(tokens[2].text)), 0, nil
+	default:
+		return "", 0, fmt.Errorf("unsupported record type %s", recordType)
+	}
+}
+
+// unescapeZoneFileString resolves the \X and \DDD escapes of a character
+// string.
+func unescapeZoneFileString(s string) string {
+	unescaped := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			unescaped.WriteByte(s[i])
+			continue
+		}
+		if i+3 < len(s) && isDigits(s[i+1:i+4]) {
+			code, _ := strconv.Atoi(s[i+1 : i+4])
+			unescaped.WriteByte(byte(code))
+			i += 3
+			continue
+		}
+		unescaped.WriteByte(s[i+1])
+		i++
+	}
+	return unescaped.String()
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if !unicode.IsDigit(c) {
+			return false
+		}
+	}
+	return true
+}
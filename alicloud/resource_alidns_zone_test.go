@@ -0,0 +1,167 @@
+package alicloud
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAliDnsZoneFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []*aliDnsZoneRecord
+		wantErr string
+	}{
+		{
+			name:    "record with default TTL",
+			content: "www IN A 192.0.2.1\n",
+			want: []*aliDnsZoneRecord{
+				{RR: "www", Type: "A", Value: "192.0.2.1", TTL: defaultAliDnsZoneTTL},
+			},
+		},
+		{
+			name: "origin and TTL directives",
+			content: "$ORIGIN sub.example.com.\n" +
+				"$TTL 1h\n" +
+				"www A 192.0.2.1\n" +
+				"@ 300 IN CNAME www\n" +
+				"$ORIGIN example.com.\n" +
+				"mail 1h30m MX 10 mx.example.net.\n",
+			want: []*aliDnsZoneRecord{
+				{RR: "www.sub", Type: "A", Value: "192.0.2.1", TTL: 3600},
+				{RR: "sub", Type: "CNAME", Value: "www.sub.example.com", TTL: 300},
+				{RR: "mail", Type: "MX", Value: "mx.example.net", TTL: 5400, Priority: 10},
+			},
+		},
+		{
+			name: "blank owner reuses the previous owner",
+			content: "api 60 A 192.0.2.1\n" +
+				"    60 A 192.0.2.2\n",
+			want: []*aliDnsZoneRecord{
+				{RR: "api", Type: "A", Value: "192.0.2.1", TTL: 60},
+				{RR: "api", Type: "A", Value: "192.0.2.2", TTL: 60},
+			},
+		},
+		{
+			name: "parentheses and comments",
+			content: "@ IN SOA ns1.example.com. admin.example.com. (\n" +
+				"    1 ; serial\n" +
+				"    3600 600 86400 600 )\n" +
+				"_sip._tcp 600 IN SRV ( 10 60\n" +
+				"    5060 sip ) ; service\n",
+			want: []*aliDnsZoneRecord{
+				{RR: "_sip._tcp", Type: "SRV", Value: "10 60 5060 sip.example.com", TTL: 600},
+			},
+		},
+		{
+			name:    "TXT escapes and concatenation",
+			content: `@ 600 TXT "v=spf1 \"a\" " "include\\x \065"` + "\n",
+			want: []*aliDnsZoneRecord{
+				{RR: "@", Type: "TXT", Value: `v=spf1 "a" include\x A`, TTL: 600},
+			},
+		},
+		{
+			name:    "empty quoted token as type",
+			content: "www \"\" A 1.2.3.4\n",
+			wantErr: "line 1: expected TTL, class or record type",
+		},
+		{
+			name:    "quoted owner",
+			content: "\"www\" A 1.2.3.4\n",
+			wantErr: "line 1: invalid owner",
+		},
+		{
+			name:    "quoted TTL",
+			content: "www \"600\" A 1.2.3.4\n",
+			wantErr: "line 1: expected TTL, class or record type",
+		},
+		{
+			name:    "name outside of the domain",
+			content: "www.example.org. A 1.2.3.4\n",
+			wantErr: "line 1: www.example.org is not in the domain example.com",
+		},
+		{
+			name:    "unbalanced parentheses",
+			content: "www A ( 1.2.3.4\n",
+			wantErr: "unbalanced parentheses",
+		},
+		{
+			name:    "unterminated quoted string",
+			content: "www TXT \"abc\n",
+			wantErr: "unterminated quoted string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAliDnsZoneFile("example.com", tt.content)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("unexpected records:\n got: %s\nwant: %s", formatZoneRecords(got), formatZoneRecords(tt.want))
+			}
+		})
+	}
+}
+
+func TestRenderAliDnsZoneFile(t *testing.T) {
+	records := []*aliDnsZoneRecord{
+		{RR: "www", Type: "A", Value: "192.0.2.1", TTL: 600},
+		{RR: "@", Type: "MX", Value: "mx.example.net", TTL: 600, Priority: 10},
+		{RR: "@", Type: "TXT", Value: "say \"hi\"\\\n", TTL: 300},
+		{RR: "alias", Type: "CNAME", Value: "www.example.com", TTL: 600},
+		{RR: "_sip._tcp", Type: "SRV", Value: "10 60 5060 sip.example.com", TTL: 600},
+		{RR: "long", Type: "TXT", Value: strings.Repeat("a", 300), TTL: 600},
+	}
+
+	content := renderAliDnsZoneFile("example.com", records)
+
+	want := "$ORIGIN example.com.\n" +
+		"$TTL 600\n" +
+		"@\t600\tIN\tMX\t10 mx.example.net.\n" +
+		"@\t300\tIN\tTXT\t\"say \\\"hi\\\"\\\\\\010\"\n" +
+		"_sip._tcp\t600\tIN\tSRV\t10 60 5060 sip.example.com.\n" +
+		"alias\t600\tIN\tCNAME\twww.example.com.\n" +
+		"long\t600\tIN\tTXT\t\"" + strings.Repeat("a", 255) + "\" \"" + strings.Repeat("a", 45) + "\"\n" +
+		"www\t600\tIN\tA\t192.0.2.1\n"
+	if content != want {
+		t.Fatalf("unexpected zone file:\n got: %q\nwant: %q", content, want)
+	}
+
+	// The rendered zone file parses back into the same records.
+	parsed, err := parseAliDnsZoneFile("example.com", content)
+	if err != nil {
+		t.Fatalf("unexpected error parsing the rendered zone file: %v", err)
+	}
+	if len(parsed) != len(records) {
+		t.Fatalf("expected %d records, got %d", len(records), len(parsed))
+	}
+	for _, record := range records {
+		found := false
+		for _, p := range parsed {
+			if reflect.DeepEqual(record, p) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("record %+v is not parsed back from the rendered zone file", *record)
+		}
+	}
+}
+
+func formatZoneRecords(records []*aliDnsZoneRecord) string {
+	lines := make([]string, 0, len(records))
+	for _, record := range records {
+		lines = append(lines, strings.Join([]string{record.RR, record.Type, record.Value}, " "))
+	}
+	return "[" + strings.Join(lines, ", ") + "]"
+}
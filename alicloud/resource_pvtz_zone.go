@@ -0,0 +1,333 @@
+package alicloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &pvtzZoneResource{}
+	_ resource.ResourceWithConfigure   = &pvtzZoneResource{}
+	_ resource.ResourceWithImportState = &pvtzZoneResource{}
+)
+
+func NewPvtzZoneResource() resource.Resource {
+	return &pvtzZoneResource{}
+}
+
+type pvtzZoneResource struct {
+	client *pvtzClient
+}
+
+type pvtzZoneResourceModel struct {
+	ZoneId          types.String `tfsdk:"zone_id"`
+	ZoneName        types.String `tfsdk:"zone_name"`
+	Remark          types.String `tfsdk:"remark"`
+	ProxyPattern    types.String `tfsdk:"proxy_pattern"`
+	ResourceGroupId types.String `tfsdk:"resource_group_id"`
+	RecordCount     types.Int64  `tfsdk:"record_count"`
+}
+
+// Metadata returns the PVTZ zone resource name.
+func (r *pvtzZoneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pvtz_zone"
+}
+
+// Schema defines the schema for the PVTZ zone resource.
+func (r *pvtzZoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alicloud PrivateZone (PVTZ) zone resource. " +
+			"The VPCs are bound to the zone with st-alicloud_pvtz_zone_vpc_attachment.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "The ID of the zone.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_name": schema.StringAttribute{
+				Description: "The name of the zone, e.g. example.internal.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"remark": schema.StringAttribute{
+				Description: "The remark of the zone.",
+				Optional:    true,
+			},
+			"proxy_pattern": schema.StringAttribute{
+				Description: "Whether the subdomains without a record in the zone are resolved " +
+					"recursively on the Internet. Valid values: ZONE (no recursion), RECORD " +
+					"(recursion). Default to ZONE.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("ZONE"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("ZONE", "RECORD"),
+				},
+			},
+			"resource_group_id": schema.StringAttribute{
+				Description: "The ID of the resource group of the zone. Default to the default resource group.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"record_count": schema.Int64Attribute{
+				Description: "The number of records in the zone.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *pvtzZoneResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).pvtzClient
+}
+
+// Create a new PVTZ zone resource.
+func (r *pvtzZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *pvtzZoneResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var zoneId string
+	addZone := func() error {
+		var err error
+		zoneId, err = r.client.AddZone(
+			plan.ZoneName.ValueString(),
+			plan.Remark.ValueString(),
+			plan.ProxyPattern.ValueString(),
+			plan.ResourceGroupId.ValueString(),
+		)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(addZone, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add PVTZ Zone",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &pvtzZoneResourceModel{
+		ZoneId: types.StringValue(zoneId),
+	}
+	if err := r.readZone(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read PVTZ Zone",
+			err.Error(),
+		)
+		return
+	}
+
+	// Keep the remark null if it is not configured
+	state.Remark = plan.Remark
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read PVTZ zone resource information.
+func (r *pvtzZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *pvtzZoneResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remark := state.Remark
+	if err := r.readZone(state); err != nil {
+		if isPvtzZoneNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read PVTZ Zone",
+			err.Error(),
+		)
+		return
+	}
+
+	// The empty remark is the same as a null remark
+	if remark.IsNull() && state.Remark.ValueString() == "" {
+		state.Remark = remark
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the PVTZ zone resource and sets the updated Terraform state on success.
+func (r *pvtzZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *pvtzZoneResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneId := state.ZoneId.ValueString()
+
+	updateZone := func() error {
+		if plan.Remark.ValueString() != state.Remark.ValueString() {
+			if err := r.client.UpdateZoneRemark(zoneId, plan.Remark.ValueString()); err != nil {
+				return handleAPIError(err)
+			}
+			state.Remark = plan.Remark
+		}
+
+		if !plan.ProxyPattern.Equal(state.ProxyPattern) {
+			if err := r.client.SetProxyPattern(zoneId, plan.ProxyPattern.ValueString()); err != nil {
+				return handleAPIError(err)
+			}
+			state.ProxyPattern = plan.ProxyPattern
+		}
+
+		if !plan.ResourceGroupId.IsUnknown() && !plan.ResourceGroupId.Equal(state.ResourceGroupId) {
+			if err := r.client.MoveResourceGroup(zoneId, plan.ResourceGroupId.ValueString()); err != nil {
+				return handleAPIError(err)
+			}
+			state.ResourceGroupId = plan.ResourceGroupId
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(updateZone, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update PVTZ Zone",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.readZone(state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read PVTZ Zone",
+			err.Error(),
+		)
+		return
+	}
+	state.Remark = plan.Remark
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the PVTZ zone resource and removes the Terraform state on success.
+func (r *pvtzZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *pvtzZoneResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteZone := func() error {
+		if err := r.client.DeleteZone(state.ZoneId.ValueString()); err != nil {
+			if isPvtzZoneNotFound(err) {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(deleteZone, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete PVTZ Zone",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *pvtzZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import zone ID and save to zone_id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("zone_id"), req, resp)
+}
+
+// readZone sets the zone attributes of the model from DescribeZoneInfo.
+func (r *pvtzZoneResource) readZone(model *pvtzZoneResourceModel) error {
+	describeZoneInfo := func() error {
+		zone, err := r.client.DescribeZoneInfo(model.ZoneId.ValueString())
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		model.ZoneName = types.StringValue(zone.ZoneName)
+		model.Remark = types.StringValue(zone.Remark)
+		model.ProxyPattern = types.StringValue(zone.ProxyPattern)
+		model.ResourceGroupId = types.StringValue(zone.ResourceGroupId)
+		model.RecordCount = types.Int64Value(zone.RecordCount)
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(describeZoneInfo, reconnectBackoff)
+}
+
+// isPvtzZoneNotFound returns whether the error is raised as the zone does not
+// exist.
+func isPvtzZoneNotFound(err error) bool {
+	if _t, ok := err.(*tea.SDKError); ok {
+		switch tea.StringValue(_t.Code) {
+		case "Zone.Invalid.Id", "Zone.NotExists", "Zone.Invalid.UserId":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,490 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                   = &pvtzZoneRecordResource{}
+	_ resource.ResourceWithConfigure      = &pvtzZoneRecordResource{}
+	_ resource.ResourceWithImportState    = &pvtzZoneRecordResource{}
+	_ resource.ResourceWithValidateConfig = &pvtzZoneRecordResource{}
+)
+
+func NewPvtzZoneRecordResource() resource.Resource {
+	return &pvtzZoneRecordResource{}
+}
+
+type pvtzZoneRecordResource struct {
+	client *pvtzClient
+}
+
+type pvtzZoneRecordResourceModel struct {
+	RecordId types.String `tfsdk:"record_id"`
+	ZoneId   types.String `tfsdk:"zone_id"`
+	RR       types.String `tfsdk:"rr"`
+	Type     types.String `tfsdk:"type"`
+	Value    types.String `tfsdk:"value"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+	Weight   types.Int64  `tfsdk:"weight"`
+	Remark   types.String `tfsdk:"remark"`
+	Status   types.String `tfsdk:"status"`
+}
+
+// Metadata returns the PVTZ zone record resource name.
+func (r *pvtzZoneRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pvtz_zone_record"
+}
+
+// Schema defines the schema for the PVTZ zone record resource.
+func (r *pvtzZoneRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alicloud PrivateZone (PVTZ) zone record resource, with the weight " +
+			"of the record managed together with the record.",
+		Attributes: map[string]schema.Attribute{
+			"record_id": schema.StringAttribute{
+				Description: "Record Id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "The ID of the zone of the record.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rr": schema.StringAttribute{
+				Description: "Host Record (RR) of the record, use @ for the zone itself.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Record Type. Valid values: A, AAAA, CNAME, MX, PTR, TXT, SRV.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("A", "AAAA", "CNAME", "MX", "PTR", "TXT", "SRV"),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "Record Value.",
+				Required:    true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "Time to live of the record in seconds. Default to 60.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(5, 86400),
+				},
+				Default: int64default.StaticInt64(60),
+			},
+			"priority": schema.Int64Attribute{
+				Description: "Priority of the MX record, required for MX records and not supported for " +
+					"the other types. Valid values: 1 to 99.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 99),
+				},
+			},
+			"weight": schema.Int64Attribute{
+				Description: "Weight of the record among the records with the same RR and type, the " +
+					"records are resolved in proportion to their weights. Valid values: 1 to 100.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 100),
+				},
+			},
+			"remark": schema.StringAttribute{
+				Description: "Remark of the record.",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the record. Valid values: ENABLE, DISABLE. Default to ENABLE.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ENABLE", "DISABLE"),
+				},
+				Default: stringdefault.StaticString("ENABLE"),
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *pvtzZoneRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).pvtzClient
+}
+
+// ValidateConfig requires the priority for MX records only, as only the
+// priority of MX records is returned by the API.
+func (r *pvtzZoneRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *pvtzZoneRecordResourceModel
+	getConfigDiags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(getConfigDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Type.IsUnknown() || config.Priority.IsUnknown() {
+		return
+	}
+
+	if config.Type.ValueString() == "MX" && config.Priority.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("priority"),
+			"[ERROR] Missing Priority",
+			"The priority is required for MX records.",
+		)
+	}
+	if config.Type.ValueString() != "MX" && !config.Priority.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("priority"),
+			"[ERROR] Invalid Priority",
+			fmt.Sprintf("The priority is only supported for MX records, got a %s record.", config.Type.ValueString()),
+		)
+	}
+}
+
+// Create a new PVTZ zone record resource.
+func (r *pvtzZoneRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *pvtzZoneRecordResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var recordId int64
+	addZoneRecord := func() error {
+		var err error
+		recordId, err = r.client.AddZoneRecord(plan.ZoneId.ValueString(), getPvtzZoneRecordRequest(plan))
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(addZoneRecord, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add PVTZ Zone Record",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &pvtzZoneRecordResourceModel{}
+	state.RecordId = types.StringValue(strconv.FormatInt(recordId, 10))
+	state.ZoneId = plan.ZoneId
+	state.RR = plan.RR
+	state.Type = plan.Type
+	state.Value = plan.Value
+	state.TTL = plan.TTL
+	state.Priority = plan.Priority
+	state.Weight = plan.Weight
+	state.Remark = types.StringNull()
+	state.Status = types.StringValue("ENABLE")
+
+	// Set the state before the remark and status, so the record is not
+	// orphaned if they fail.
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateRemarkAndStatus(plan, state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update PVTZ Zone Record",
+			err.Error(),
+		)
+	}
+
+	setStateDiags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read PVTZ zone record resource information.
+func (r *pvtzZoneRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *pvtzZoneRecordResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the zone ID and the record ID are set when importing
+	importing := state.Type.IsNull()
+
+	var records []*pvtzZoneRecord
+	describeZoneRecords := func() error {
+		var err error
+		records, err = r.client.DescribeZoneRecords(state.ZoneId.ValueString())
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(describeZoneRecords, reconnectBackoff)
+	if err != nil {
+		if isPvtzZoneNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe PVTZ Zone Records",
+			err.Error(),
+		)
+		return
+	}
+
+	var record *pvtzZoneRecord
+	for _, x := range records {
+		if strconv.FormatInt(x.RecordId, 10) == state.RecordId.ValueString() {
+			record = x
+			break
+		}
+	}
+	if record == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.RR = types.StringValue(record.Rr)
+	state.Type = types.StringValue(record.Type)
+	state.Value = types.StringValue(record.Value)
+	state.TTL = types.Int64Value(record.Ttl)
+	state.Status = types.StringValue(record.Status)
+	if record.Type == "MX" {
+		state.Priority = types.Int64Value(record.Priority)
+	} else {
+		state.Priority = types.Int64Null()
+	}
+	if !(state.Remark.IsNull() && record.Remark == "") {
+		state.Remark = types.StringValue(record.Remark)
+	}
+
+	// The records always have a weight, it is only kept in the state when
+	// it is configured, or when the record is weighted against the other
+	// records of the same RR and type on import.
+	if !state.Weight.IsNull() {
+		state.Weight = types.Int64Value(record.Weight)
+	} else if importing {
+		for _, x := range records {
+			if x.RecordId != record.RecordId && x.Rr == record.Rr && x.Type == record.Type {
+				state.Weight = types.Int64Value(record.Weight)
+				break
+			}
+		}
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the PVTZ zone record resource and sets the updated Terraform state on success.
+func (r *pvtzZoneRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *pvtzZoneRecordResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordId, err := strconv.ParseInt(state.RecordId.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid PVTZ Zone Record ID",
+			err.Error(),
+		)
+		return
+	}
+
+	// UPDATE RECORD
+	if !(plan.RR.Equal(state.RR) && plan.Type.Equal(state.Type) && plan.Value.Equal(state.Value) &&
+		plan.TTL.Equal(state.TTL) && plan.Priority.Equal(state.Priority) && plan.Weight.Equal(state.Weight)) {
+		updateZoneRecord := func() error {
+			if err := r.client.UpdateZoneRecord(recordId, getPvtzZoneRecordRequest(plan)); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		// Retry backoff
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		err := backoff.Retry(updateZoneRecord, reconnectBackoff)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update PVTZ Zone Record",
+				err.Error(),
+			)
+			return
+		}
+
+		state.RR = plan.RR
+		state.Type = plan.Type
+		state.Value = plan.Value
+		state.TTL = plan.TTL
+		state.Priority = plan.Priority
+		state.Weight = plan.Weight
+	}
+
+	// UPDATE REMARK AND STATUS
+	if err := r.updateRemarkAndStatus(plan, state); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update PVTZ Zone Record",
+			err.Error(),
+		)
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the PVTZ zone record resource and removes the Terraform state on success.
+func (r *pvtzZoneRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *pvtzZoneRecordResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordId, err := strconv.ParseInt(state.RecordId.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid PVTZ Zone Record ID",
+			err.Error(),
+		)
+		return
+	}
+
+	deleteZoneRecord := func() error {
+		if err := r.client.DeleteZoneRecord(recordId); err != nil {
+			if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "Record.Invalid.Id" {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(deleteZoneRecord, reconnectBackoff)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete PVTZ Zone Record",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *pvtzZoneRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by <zone_id>/<record_id>
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format of <zone_id>/<record_id>, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("record_id"), parts[1])...)
+}
+
+// updateRemarkAndStatus sets the remark and the status of the record to the
+// plan, the state is updated with each of them once set.
+func (r *pvtzZoneRecordResource) updateRemarkAndStatus(plan, state *pvtzZoneRecordResourceModel) error {
+	recordId, err := strconv.ParseInt(state.RecordId.ValueString(), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	updateRemarkAndStatus := func() error {
+		if plan.Remark.ValueString() != state.Remark.ValueString() {
+			if err := r.client.UpdateRecordRemark(recordId, plan.Remark.ValueString()); err != nil {
+				return handleAPIError(err)
+			}
+		}
+		state.Remark = plan.Remark
+
+		if !plan.Status.Equal(state.Status) {
+			if err := r.client.SetZoneRecordStatus(recordId, plan.Status.ValueString()); err != nil {
+				return handleAPIError(err)
+			}
+			state.Status = plan.Status
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateRemarkAndStatus, reconnectBackoff)
+}
+
+func getPvtzZoneRecordRequest(plan *pvtzZoneRecordResourceModel) *pvtzZoneRecordRequest {
+	request := &pvtzZoneRecordRequest{
+		Rr:    plan.RR.ValueString(),
+		Type:  plan.Type.ValueString(),
+		Value: plan.Value.ValueString(),
+		Ttl:   plan.TTL.ValueInt64(),
+	}
+	if !plan.Priority.IsNull() {
+		request.Priority = tea.Int64(plan.Priority.ValueInt64())
+	}
+	if !plan.Weight.IsNull() {
+		request.Weight = tea.Int64(plan.Weight.ValueInt64())
+	}
+	return request
+}
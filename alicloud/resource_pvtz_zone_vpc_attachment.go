@@ -0,0 +1,281 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &pvtzZoneVpcAttachmentResource{}
+	_ resource.ResourceWithConfigure   = &pvtzZoneVpcAttachmentResource{}
+	_ resource.ResourceWithImportState = &pvtzZoneVpcAttachmentResource{}
+)
+
+// BindZoneVpc replaces all the VPCs bound to the zone, the attachments of the
+// same zone are serialized so they don't overwrite each other's bindings.
+var pvtzZoneVpcMutexes sync.Map
+
+func NewPvtzZoneVpcAttachmentResource() resource.Resource {
+	return &pvtzZoneVpcAttachmentResource{}
+}
+
+type pvtzZoneVpcAttachmentResource struct {
+	client *pvtzClient
+}
+
+type pvtzZoneVpcAttachmentResourceModel struct {
+	ZoneId   types.String `tfsdk:"zone_id"`
+	VpcId    types.String `tfsdk:"vpc_id"`
+	RegionId types.String `tfsdk:"region_id"`
+}
+
+// Metadata returns the PVTZ zone VPC attachment resource name.
+func (r *pvtzZoneVpcAttachmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pvtz_zone_vpc_attachment"
+}
+
+// Schema defines the schema for the PVTZ zone VPC attachment resource.
+func (r *pvtzZoneVpcAttachmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a Alicloud PrivateZone (PVTZ) zone VPC attachment resource, which binds " +
+			"a single VPC to the zone. Unlike alicloud_pvtz_zone_attachment, the VPCs bound to the " +
+			"zone outside of this resource are kept.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "The ID of the zone.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vpc_id": schema.StringAttribute{
+				Description: "The ID of the VPC to bind to the zone.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"region_id": schema.StringAttribute{
+				Description: "The region of the VPC. Default to the region of the provider.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *pvtzZoneVpcAttachmentResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).pvtzClient
+}
+
+// Create binds the VPC to the zone.
+func (r *pvtzZoneVpcAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *pvtzZoneVpcAttachmentResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.RegionId.IsUnknown() {
+		plan.RegionId = types.StringValue(tea.StringValue(r.client.RegionId))
+	}
+
+	err := r.updateZoneVpcs(plan.ZoneId.ValueString(), func(vpcs []*pvtzVpc) ([]*pvtzVpc, bool) {
+		for _, vpc := range vpcs {
+			if vpc.VpcId == plan.VpcId.ValueString() {
+				return vpcs, false
+			}
+		}
+		return append(vpcs, &pvtzVpc{
+			VpcId:    plan.VpcId.ValueString(),
+			RegionId: plan.RegionId.ValueString(),
+		}), true
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Bind VPC to PVTZ Zone",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &pvtzZoneVpcAttachmentResourceModel{}
+	state.ZoneId = plan.ZoneId
+	state.VpcId = plan.VpcId
+	state.RegionId = plan.RegionId
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read PVTZ zone VPC attachment resource information.
+func (r *pvtzZoneVpcAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *pvtzZoneVpcAttachmentResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var zone *pvtzZone
+	describeZoneInfo := func() error {
+		var err error
+		zone, err = r.client.DescribeZoneInfo(state.ZoneId.ValueString())
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err := backoff.Retry(describeZoneInfo, reconnectBackoff)
+	if err != nil {
+		if isPvtzZoneNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read PVTZ Zone",
+			err.Error(),
+		)
+		return
+	}
+
+	bound := false
+	for _, vpc := range zone.BindVpcs.Vpc {
+		if vpc.VpcId == state.VpcId.ValueString() {
+			state.RegionId = types.StringValue(vpc.RegionId)
+			bound = true
+			break
+		}
+	}
+	if !bound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update is not supported, all the attributes require replacement.
+func (r *pvtzZoneVpcAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *pvtzZoneVpcAttachmentResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete unbinds the VPC from the zone, the other VPCs are kept bound.
+func (r *pvtzZoneVpcAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *pvtzZoneVpcAttachmentResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.updateZoneVpcs(state.ZoneId.ValueString(), func(vpcs []*pvtzVpc) ([]*pvtzVpc, bool) {
+		remaining := make([]*pvtzVpc, 0, len(vpcs))
+		for _, vpc := range vpcs {
+			if vpc.VpcId != state.VpcId.ValueString() {
+				remaining = append(remaining, vpc)
+			}
+		}
+		return remaining, len(remaining) != len(vpcs)
+	})
+	if err != nil {
+		if isPvtzZoneNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Unbind VPC from PVTZ Zone",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *pvtzZoneVpcAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by <zone_id>/<vpc_id>
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid Import ID",
+			fmt.Sprintf("Expected import ID in the format of <zone_id>/<vpc_id>, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vpc_id"), parts[1])...)
+}
+
+// updateZoneVpcs reads the VPCs currently bound to the zone and binds the VPCs
+// returned by modify instead, BindZoneVpc is skipped if modify reports no
+// change.
+func (r *pvtzZoneVpcAttachmentResource) updateZoneVpcs(zoneId string, modify func([]*pvtzVpc) ([]*pvtzVpc, bool)) error {
+	mutex, _ := pvtzZoneVpcMutexes.LoadOrStore(zoneId, &sync.Mutex{})
+	mutex.(*sync.Mutex).Lock()
+	defer mutex.(*sync.Mutex).Unlock()
+
+	bindZoneVpc := func() error {
+		zone, err := r.client.DescribeZoneInfo(zoneId)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		vpcs, changed := modify(zone.BindVpcs.Vpc)
+		if !changed {
+			return nil
+		}
+
+		if err := r.client.BindZoneVpc(zoneId, vpcs); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	// Retry backoff
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(bindZoneVpc, reconnectBackoff)
+}
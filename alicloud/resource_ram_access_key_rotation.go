@@ -0,0 +1,680 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource               = &ramAccessKeyRotationResource{}
+	_ resource.ResourceWithConfigure  = &ramAccessKeyRotationResource{}
+	_ resource.ResourceWithModifyPlan = &ramAccessKeyRotationResource{}
+)
+
+func NewRamAccessKeyRotationResource() resource.Resource {
+	return &ramAccessKeyRotationResource{}
+}
+
+type ramAccessKeyRotationResource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramAccessKeyRotationResourceModel struct {
+	UserName        types.String `tfsdk:"user_name"`
+	RotationDays    types.Int64  `tfsdk:"rotation_days"`
+	GracePeriod     types.Int64  `tfsdk:"grace_period"`
+	PgpKey          types.String `tfsdk:"pgp_key"`
+	AccessKeyId     types.String `tfsdk:"access_key_id"`
+	Secret          types.String `tfsdk:"secret"`
+	EncryptedSecret types.String `tfsdk:"encrypted_secret"`
+	KeyFingerprint  types.String `tfsdk:"key_fingerprint"`
+	CreateDate      types.String `tfsdk:"create_date"`
+	RotationDate    types.String `tfsdk:"rotation_date"`
+	AccessKeys      types.List   `tfsdk:"access_keys"`
+}
+
+type ramAccessKeyDetail struct {
+	AccessKeyId    types.String `tfsdk:"access_key_id"`
+	Status         types.String `tfsdk:"status"`
+	CreateDate     types.String `tfsdk:"create_date"`
+	DeactivateDate types.String `tfsdk:"deactivate_date"`
+}
+
+func (r *ramAccessKeyRotationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_access_key_rotation"
+}
+
+func (r *ramAccessKeyRotationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a RAM Access Key Rotation resource, which keeps up to two access keys " +
+			"for a RAM user. Once the current access key is older than rotation_days, a new access " +
+			"key is created during apply and the previous access key is deactivated, then deleted " +
+			"after the grace period.",
+		Attributes: map[string]schema.Attribute{
+			"user_name": schema.StringAttribute{
+				Description: "The username of the RAM user.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_days": schema.Int64Attribute{
+				Description: "The number of days after which the access key is rotated. " +
+					"It is evaluated during plan.",
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"grace_period": schema.Int64Attribute{
+				Description: "The number of days a deactivated access key is kept before it is deleted, " +
+					"so that it can be activated again if anything still depends on it. Default to 7.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				Default: int64default.StaticInt64(7),
+			},
+			"pgp_key": schema.StringAttribute{
+				Description: "The PGP public key, either ASCII armored or base64-encoded, used to encrypt " +
+					"the secret of the access key. If set, the secret is only exposed in encrypted_secret. " +
+					"Changing it rotates the access key.",
+				Optional: true,
+			},
+			"access_key_id": schema.StringAttribute{
+				Description: "The ID of the current access key.",
+				Computed:    true,
+			},
+			"secret": schema.StringAttribute{
+				Description: "The secret of the current access key. Null if pgp_key is set.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"encrypted_secret": schema.StringAttribute{
+				Description: "The base64-encoded secret of the current access key encrypted with pgp_key. " +
+					"Null if pgp_key is not set.",
+				Computed: true,
+			},
+			"key_fingerprint": schema.StringAttribute{
+				Description: "The fingerprint of the PGP key used to encrypt the secret.",
+				Computed:    true,
+			},
+			"create_date": schema.StringAttribute{
+				Description: "The creation date of the current access key.",
+				Computed:    true,
+			},
+			"rotation_date": schema.StringAttribute{
+				Description: "The date from which the current access key is rotated on the next apply.",
+				Computed:    true,
+			},
+			"access_keys": schema.ListNestedAttribute{
+				Description: "The access keys managed by this resource, including the previous access key " +
+					"during its grace period.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"access_key_id": schema.StringAttribute{
+							Description: "The ID of the access key.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of the access key. Valid values: Active, Inactive.",
+							Computed:    true,
+						},
+						"create_date": schema.StringAttribute{
+							Description: "The creation date of the access key.",
+							Computed:    true,
+						},
+						"deactivate_date": schema.StringAttribute{
+							Description: "The date on which the access key was deactivated by rotation.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ramAccessKeyRotationResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (r *ramAccessKeyRotationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ramAccessKeyRotationResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := &ramAccessKeyRotationResourceModel{}
+	state.UserName = plan.UserName
+	state.RotationDays = plan.RotationDays
+	state.GracePeriod = plan.GracePeriod
+	state.PgpKey = plan.PgpKey
+
+	accessKey, err := r.createAccessKey(state)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Create Access Key.",
+			err.Error(),
+		)
+		return
+	}
+
+	accessKeys, diags := types.ListValueFrom(ctx, ramAccessKeyDetailType(), []*ramAccessKeyDetail{accessKey})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.AccessKeys = accessKeys
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramAccessKeyRotationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ramAccessKeyRotationResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existingAccessKeys, err := r.listAccessKeys(state.UserName.ValueString())
+	if err != nil {
+		if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "EntityNotExist.User" {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to List Access Keys",
+			err.Error(),
+		)
+		return
+	}
+
+	// The current access key is recreated if it has been deleted outside
+	// Terraform, while a previous access key which has been deleted outside
+	// Terraform is simply dropped.
+	if existingAccessKeys[state.AccessKeyId.ValueString()] == nil {
+		resp.Diagnostics.AddWarning(
+			"Access Key Not Found.",
+			fmt.Sprintf("The access key %s of user %s has been deleted outside Terraform, a new access key will be created.",
+				state.AccessKeyId.ValueString(), state.UserName.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	accessKeys, diags := getRamAccessKeyDetails(ctx, state.AccessKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stillExistingAccessKeys := make([]*ramAccessKeyDetail, 0)
+	for _, accessKey := range accessKeys {
+		if existingAccessKey := existingAccessKeys[accessKey.AccessKeyId.ValueString()]; existingAccessKey != nil {
+			accessKey.Status = types.StringValue(tea.StringValue(existingAccessKey.Status))
+			stillExistingAccessKeys = append(stillExistingAccessKeys, accessKey)
+		}
+	}
+
+	state.AccessKeys, diags = types.ListValueFrom(ctx, ramAccessKeyDetailType(), stillExistingAccessKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramAccessKeyRotationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *ramAccessKeyRotationResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessKeys, diags := getRamAccessKeyDetails(ctx, state.AccessKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.RotationDays = plan.RotationDays
+	state.GracePeriod = plan.GracePeriod
+	state.PgpKey = plan.PgpKey
+	now := time.Now().UTC()
+
+	// The rotation is decided in ModifyPlan, which marks the current access
+	// key as unknown.
+	if plan.AccessKeyId.IsUnknown() {
+		// A RAM user can only have two access keys, so the previous access key
+		// is deleted before its grace period ends.
+		remainingAccessKeys := make([]*ramAccessKeyDetail, 0)
+		for _, accessKey := range accessKeys {
+			if accessKey.AccessKeyId.Equal(state.AccessKeyId) {
+				remainingAccessKeys = append(remainingAccessKeys, accessKey)
+				continue
+			}
+			resp.Diagnostics.AddWarning(
+				"Previous Access Key Deleted Before Grace Period Ends.",
+				fmt.Sprintf("The access key %s is deleted to make room for the new access key, as a RAM user can only have two access keys.",
+					accessKey.AccessKeyId.ValueString()),
+			)
+			if err := r.deleteAccessKey(state.UserName.ValueString(), accessKey.AccessKeyId.ValueString()); err != nil {
+				resp.Diagnostics.AddError(
+					"[API ERROR] Failed to Delete Access Key.",
+					err.Error(),
+				)
+				return
+			}
+		}
+		accessKeys = remainingAccessKeys
+
+		previousAccessKeyId := state.AccessKeyId.ValueString()
+		newAccessKey, err := r.createAccessKey(state)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Create Access Key.",
+				err.Error(),
+			)
+			return
+		}
+
+		// Save the new access key before deactivating the previous access key,
+		// so the new access key and its secret are not lost if the
+		// deactivation fails.
+		state.AccessKeys, diags = types.ListValueFrom(ctx, ramAccessKeyDetailType(), append(accessKeys, newAccessKey))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		setStateDiags := resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(setStateDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if err := r.updateAccessKeyStatus(state.UserName.ValueString(), previousAccessKeyId, "Inactive"); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Deactivate Access Key.",
+				err.Error(),
+			)
+			return
+		}
+		for _, accessKey := range accessKeys {
+			accessKey.Status = types.StringValue("Inactive")
+			accessKey.DeactivateDate = types.StringValue(now.Format(time.RFC3339))
+		}
+		accessKeys = append(accessKeys, newAccessKey)
+	}
+
+	// Delete the deactivated access keys once their grace period has ended.
+	remainingAccessKeys := make([]*ramAccessKeyDetail, 0)
+	for _, accessKey := range accessKeys {
+		if !isRamAccessKeyGracePeriodEnded(accessKey, state.GracePeriod.ValueInt64(), now) {
+			remainingAccessKeys = append(remainingAccessKeys, accessKey)
+			continue
+		}
+		if err := r.deleteAccessKey(state.UserName.ValueString(), accessKey.AccessKeyId.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Delete Access Key.",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	state.AccessKeys, diags = types.ListValueFrom(ctx, ramAccessKeyDetailType(), remainingAccessKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.RotationDate, diags = getRamAccessKeyRotationDate(state.CreateDate, state.RotationDays)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramAccessKeyRotationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *ramAccessKeyRotationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessKeys, diags := getRamAccessKeyDetails(ctx, state.AccessKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, accessKey := range accessKeys {
+		if err := r.deleteAccessKey(state.UserName.ValueString(), accessKey.AccessKeyId.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Delete Access Key.",
+				err.Error(),
+			)
+			return
+		}
+	}
+}
+
+func (r *ramAccessKeyRotationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// A new access key is created anyway when the resource is created.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state *ramAccessKeyRotationResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The user is replaced, so every computed attribute is unknown.
+	if !plan.UserName.Equal(state.UserName) {
+		return
+	}
+
+	accessKeys, diags := getRamAccessKeyDetails(ctx, state.AccessKeys)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.AccessKeyId = state.AccessKeyId
+	plan.Secret = state.Secret
+	plan.EncryptedSecret = state.EncryptedSecret
+	plan.KeyFingerprint = state.KeyFingerprint
+	plan.CreateDate = state.CreateDate
+	plan.AccessKeys = state.AccessKeys
+	plan.RotationDate, diags = getRamAccessKeyRotationDate(state.CreateDate, plan.RotationDays)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now().UTC()
+	rotationDate, err := time.Parse(time.RFC3339, plan.RotationDate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Parse Rotation Date.",
+			err.Error(),
+		)
+		return
+	}
+
+	// The secret can only be encrypted with a new PGP key by creating a new
+	// access key, as the secret is not kept in state once encrypted.
+	if !now.Before(rotationDate) || !plan.PgpKey.Equal(state.PgpKey) {
+		plan.AccessKeyId = types.StringUnknown()
+		plan.Secret = types.StringUnknown()
+		plan.EncryptedSecret = types.StringUnknown()
+		plan.KeyFingerprint = types.StringUnknown()
+		plan.CreateDate = types.StringUnknown()
+		plan.RotationDate = types.StringUnknown()
+		plan.AccessKeys = types.ListUnknown(ramAccessKeyDetailType())
+	} else {
+		for _, accessKey := range accessKeys {
+			if isRamAccessKeyGracePeriodEnded(accessKey, plan.GracePeriod.ValueInt64(), now) {
+				plan.AccessKeys = types.ListUnknown(ramAccessKeyDetailType())
+				break
+			}
+		}
+	}
+
+	setPlanDiags := resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(setPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// createAccessKey creates a new access key for the user and sets it as the
+// current access key of the state.
+func (r *ramAccessKeyRotationResource) createAccessKey(state *ramAccessKeyRotationResourceModel) (*ramAccessKeyDetail, error) {
+	var accessKey *alicloudRamClient.CreateAccessKeyResponseBodyAccessKey
+	createAccessKey := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		createAccessKeyRequest := &alicloudRamClient.CreateAccessKeyRequest{
+			UserName: tea.String(state.UserName.ValueString()),
+		}
+
+		createAccessKeyResponse, err := r.client.CreateAccessKeyWithOptions(createAccessKeyRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		accessKey = createAccessKeyResponse.Body.AccessKey
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(createAccessKey, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	state.AccessKeyId = types.StringValue(tea.StringValue(accessKey.AccessKeyId))
+	state.CreateDate = types.StringValue(tea.StringValue(accessKey.CreateDate))
+	if state.PgpKey.IsNull() {
+		state.Secret = types.StringValue(tea.StringValue(accessKey.AccessKeySecret))
+		state.EncryptedSecret = types.StringNull()
+		state.KeyFingerprint = types.StringNull()
+	} else {
+		encryptedSecret, keyFingerprint, err := encryptWithPgpKey(state.PgpKey.ValueString(), tea.StringValue(accessKey.AccessKeySecret))
+		if err != nil {
+			// The access key is useless without its secret.
+			if deleteErr := r.deleteAccessKey(state.UserName.ValueString(), tea.StringValue(accessKey.AccessKeyId)); deleteErr != nil {
+				return nil, fmt.Errorf("%s, and failed to delete the access key %s: %s",
+					err.Error(), tea.StringValue(accessKey.AccessKeyId), deleteErr.Error())
+			}
+			return nil, err
+		}
+		state.Secret = types.StringNull()
+		state.EncryptedSecret = types.StringValue(encryptedSecret)
+		state.KeyFingerprint = types.StringValue(keyFingerprint)
+	}
+
+	var diags diag.Diagnostics
+	state.RotationDate, diags = getRamAccessKeyRotationDate(state.CreateDate, state.RotationDays)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to parse the creation date %s of the access key", state.CreateDate.ValueString())
+	}
+
+	return &ramAccessKeyDetail{
+		AccessKeyId:    state.AccessKeyId,
+		Status:         types.StringValue(tea.StringValue(accessKey.Status)),
+		CreateDate:     state.CreateDate,
+		DeactivateDate: types.StringNull(),
+	}, nil
+}
+
+func (r *ramAccessKeyRotationResource) listAccessKeys(userName string) (map[string]*alicloudRamClient.ListAccessKeysResponseBodyAccessKeysAccessKey, error) {
+	accessKeys := make(map[string]*alicloudRamClient.ListAccessKeysResponseBodyAccessKeysAccessKey)
+
+	listAccessKeys := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		listAccessKeysRequest := &alicloudRamClient.ListAccessKeysRequest{
+			UserName: tea.String(userName),
+		}
+
+		listAccessKeysResponse, err := r.client.ListAccessKeysWithOptions(listAccessKeysRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		if listAccessKeysResponse.Body.AccessKeys != nil {
+			for _, accessKey := range listAccessKeysResponse.Body.AccessKeys.AccessKey {
+				accessKeys[tea.StringValue(accessKey.AccessKeyId)] = accessKey
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(listAccessKeys, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	return accessKeys, nil
+}
+
+func (r *ramAccessKeyRotationResource) updateAccessKeyStatus(userName, accessKeyId, status string) error {
+	updateAccessKey := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		updateAccessKeyRequest := &alicloudRamClient.UpdateAccessKeyRequest{
+			UserName:        tea.String(userName),
+			UserAccessKeyId: tea.String(accessKeyId),
+			Status:          tea.String(status),
+		}
+
+		if _, err := r.client.UpdateAccessKeyWithOptions(updateAccessKeyRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(updateAccessKey, reconnectBackoff)
+}
+
+func (r *ramAccessKeyRotationResource) deleteAccessKey(userName, accessKeyId string) error {
+	deleteAccessKey := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteAccessKeyRequest := &alicloudRamClient.DeleteAccessKeyRequest{
+			UserName:        tea.String(userName),
+			UserAccessKeyId: tea.String(accessKeyId),
+		}
+
+		if _, err := r.client.DeleteAccessKeyWithOptions(deleteAccessKeyRequest, runtime); err != nil {
+			// The access key may have been deleted outside Terraform.
+			if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "EntityNotExist.User.AccessKey" {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(deleteAccessKey, reconnectBackoff)
+}
+
+func ramAccessKeyDetailType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"access_key_id":   types.StringType,
+			"status":          types.StringType,
+			"create_date":     types.StringType,
+			"deactivate_date": types.StringType,
+		},
+	}
+}
+
+func getRamAccessKeyDetails(ctx context.Context, accessKeys types.List) ([]*ramAccessKeyDetail, diag.Diagnostics) {
+	accessKeyDetails := make([]*ramAccessKeyDetail, 0)
+	if accessKeys.IsNull() || accessKeys.IsUnknown() {
+		return accessKeyDetails, nil
+	}
+
+	diags := accessKeys.ElementsAs(ctx, &accessKeyDetails, false)
+	return accessKeyDetails, diags
+}
+
+// getRamAccessKeyRotationDate returns the date on which an access key created
+// on createDate is due for rotation.
+func getRamAccessKeyRotationDate(createDate types.String, rotationDays types.Int64) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	createTime, err := time.Parse(time.RFC3339, createDate.ValueString())
+	if err != nil {
+		diags.AddError(
+			"[ERROR] Failed to Parse Access Key Creation Date.",
+			err.Error(),
+		)
+		return types.StringNull(), diags
+	}
+
+	rotationTime := createTime.Add(time.Duration(rotationDays.ValueInt64()) * 24 * time.Hour)
+	return types.StringValue(rotationTime.UTC().Format(time.RFC3339)), diags
+}
+
+func isRamAccessKeyGracePeriodEnded(accessKey *ramAccessKeyDetail, gracePeriod int64, now time.Time) bool {
+	if accessKey.DeactivateDate.IsNull() {
+		return false
+	}
+
+	deactivateTime, err := time.Parse(time.RFC3339, accessKey.DeactivateDate.ValueString())
+	if err != nil {
+		return false
+	}
+
+	return !now.Before(deactivateTime.Add(time.Duration(gracePeriod) * 24 * time.Hour))
+}
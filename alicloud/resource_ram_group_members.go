@@ -0,0 +1,378 @@
+package alicloud
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &ramGroupMembersResource{}
+	_ resource.ResourceWithConfigure   = &ramGroupMembersResource{}
+	_ resource.ResourceWithImportState = &ramGroupMembersResource{}
+)
+
+func NewRamGroupMembersResource() resource.Resource {
+	return &ramGroupMembersResource{}
+}
+
+type ramGroupMembersResource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramGroupMembersResourceModel struct {
+	GroupName types.String `tfsdk:"group_name"`
+	UserNames types.Set    `tfsdk:"user_names"`
+}
+
+func (r *ramGroupMembersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_group_members"
+}
+
+func (r *ramGroupMembersResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a RAM Group Members resource that manages a set of users of a RAM group. " +
+			"The resource is non-exclusive, members of the group which are not declared in user_names " +
+			"are not touched.",
+		Attributes: map[string]schema.Attribute{
+			"group_name": schema.StringAttribute{
+				Description: "The group name.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_names": schema.SetAttribute{
+				Description: "The usernames of the RAM group members.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *ramGroupMembersResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (r *ramGroupMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ramGroupMembersResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var userNames []string
+	resp.Diagnostics.Append(plan.UserNames.ElementsAs(ctx, &userNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.addUsersToGroup(plan.GroupName.ValueString(), userNames); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add Users to Group.",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &ramGroupMembersResourceModel{}
+	state.GroupName = plan.GroupName
+	state.UserNames = plan.UserNames
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramGroupMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ramGroupMembersResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := r.listUsersForGroup(state.GroupName.ValueString())
+	if err != nil {
+		if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "EntityNotExist.Group" {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Users for Group",
+			err.Error(),
+		)
+		return
+	}
+
+	// Only the declared users are kept in state, so that members managed
+	// elsewhere do not show up as drift. After import, user_names is kept null
+	// so that the config decides which members are managed, instead of
+	// adopting every member and removing the undeclared ones on apply.
+	if state.UserNames.IsNull() {
+		setStateDiags := resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(setStateDiags...)
+		return
+	}
+
+	var stateUserNames []string
+	resp.Diagnostics.Append(state.UserNames.ElementsAs(ctx, &stateUserNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	userNames := make([]string, 0)
+	for _, userName := range stateUserNames {
+		if members[userName] {
+			userNames = append(userNames, userName)
+		}
+	}
+	sort.Strings(userNames)
+
+	userNamesSet, diags := types.SetValueFrom(ctx, types.StringType, userNames)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.UserNames = userNamesSet
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramGroupMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *ramGroupMembersResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planUserNames, stateUserNames []string
+	resp.Diagnostics.Append(plan.UserNames.ElementsAs(ctx, &planUserNames, false)...)
+	resp.Diagnostics.Append(state.UserNames.ElementsAs(ctx, &stateUserNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	usersToAdd, usersToRemove := diffUserNames(stateUserNames, planUserNames)
+
+	if err := r.removeUsersFromGroup(plan.GroupName.ValueString(), usersToRemove); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Remove Users from Group",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.addUsersToGroup(plan.GroupName.ValueString(), usersToAdd); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add Users to Group.",
+			err.Error(),
+		)
+		return
+	}
+
+	state.GroupName = plan.GroupName
+	state.UserNames = plan.UserNames
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramGroupMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *ramGroupMembersResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var userNames []string
+	resp.Diagnostics.Append(state.UserNames.ElementsAs(ctx, &userNames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.removeUsersFromGroup(state.GroupName.ValueString(), userNames); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Remove Users from Group",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ramGroupMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by the group name only, the members in user_names of the config
+	// are added on the next apply and no existing member is removed.
+	resource.ImportStatePassthroughID(ctx, path.Root("group_name"), req, resp)
+}
+
+// listUsersForGroup pages through all the members of the group once, so that
+// the membership of every declared user is checked with the same listing.
+func (r *ramGroupMembersResource) listUsersForGroup(groupName string) (map[string]bool, error) {
+	members := make(map[string]bool)
+	var marker *string
+
+	for {
+		var listUsersForGroupResponse *alicloudRamClient.ListUsersForGroupResponse
+		listUsersForGroup := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			listUsersForGroupRequest := &alicloudRamClient.ListUsersForGroupRequest{
+				GroupName: tea.String(groupName),
+				Marker:    marker,
+				MaxItems:  tea.Int32(1000),
+			}
+
+			var err error
+			listUsersForGroupResponse, err = r.client.ListUsersForGroupWithOptions(listUsersForGroupRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(listUsersForGroup, reconnectBackoff); err != nil {
+			return nil, err
+		}
+
+		if listUsersForGroupResponse.Body.Users != nil {
+			for _, user := range listUsersForGroupResponse.Body.Users.User {
+				members[tea.StringValue(user.UserName)] = true
+			}
+		}
+
+		if !tea.BoolValue(listUsersForGroupResponse.Body.IsTruncated) {
+			break
+		}
+		marker = listUsersForGroupResponse.Body.Marker
+	}
+
+	return members, nil
+}
+
+func (r *ramGroupMembersResource) addUsersToGroup(groupName string, userNames []string) error {
+	for _, userName := range userNames {
+		addUserToGroupRequest := &alicloudRamClient.AddUserToGroupRequest{
+			UserName:  tea.String(userName),
+			GroupName: tea.String(groupName),
+		}
+
+		addUserToGroup := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			if _, err := r.client.AddUserToGroupWithOptions(addUserToGroupRequest, runtime); err != nil {
+				// The user may have been added to the group outside Terraform.
+				if _t, ok := err.(*tea.SDKError); ok && strings.HasPrefix(tea.StringValue(_t.Code), "EntityAlreadyExists") {
+					return nil
+				}
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(addUserToGroup, reconnectBackoff); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ramGroupMembersResource) removeUsersFromGroup(groupName string, userNames []string) error {
+	for _, userName := range userNames {
+		removeUserFromGroupRequest := &alicloudRamClient.RemoveUserFromGroupRequest{
+			UserName:  tea.String(userName),
+			GroupName: tea.String(groupName),
+		}
+
+		removeUserFromGroup := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			if _, err := r.client.RemoveUserFromGroupWithOptions(removeUserFromGroupRequest, runtime); err != nil {
+				// The user or the membership may have been removed outside Terraform.
+				if _t, ok := err.(*tea.SDKError); ok && strings.HasPrefix(tea.StringValue(_t.Code), "EntityNotExist") {
+					return nil
+				}
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(removeUserFromGroup, reconnectBackoff); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// diffUserNames returns the users which are only in the new list, and the
+// users which are only in the old list.
+func diffUserNames(oldUserNames, newUserNames []string) (usersToAdd, usersToRemove []string) {
+	oldUsers := make(map[string]bool)
+	for _, userName := range oldUserNames {
+		oldUsers[userName] = true
+	}
+	newUsers := make(map[string]bool)
+	for _, userName := range newUserNames {
+		newUsers[userName] = true
+	}
+
+	for _, userName := range newUserNames {
+		if !oldUsers[userName] {
+			usersToAdd = append(usersToAdd, userName)
+		}
+	}
+	for _, userName := range oldUserNames {
+		if !newUsers[userName] {
+			usersToRemove = append(usersToRemove, userName)
+		}
+	}
+
+	return
+}
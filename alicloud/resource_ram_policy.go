@@ -1,19 +1,28 @@
 package alicloud
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
@@ -23,10 +32,25 @@ import (
 
 const maxLength = 6144
 
+// The names of the attached policies are recorded in the description of the
+// combined policies, which is limited to 1024 characters.
+const (
+	ramPolicyDescriptionPrefix    = "Combined by st-alicloud_ram_policy from: "
+	ramPolicyDescriptionMaxLength = 1024
+)
+
+// The RAM quotas are not exposed by the RAM API, these are the default
+// quotas documented by Alibaba Cloud.
+const (
+	defaultRamPolicyQuota     = 1500
+	defaultRamAttachmentQuota = 5
+)
+
 var (
 	_ resource.Resource                = &ramPolicyResource{}
 	_ resource.ResourceWithConfigure   = &ramPolicyResource{}
 	_ resource.ResourceWithImportState = &ramPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &ramPolicyResource{}
 )
 
 func NewRamPolicyResource() resource.Resource {
@@ -38,9 +62,18 @@ type ramPolicyResource struct {
 }
 
 type ramPolicyResourceModel struct {
-	AttachedPolicies types.List   `tfsdk:"attached_policies"`
-	Policies         types.List   `tfsdk:"policies"`
-	UserName         types.String `tfsdk:"user_name"`
+	AttachedPolicies     types.List   `tfsdk:"attached_policies"`
+	AttachedPoliciesHash types.Map    `tfsdk:"attached_policies_hash"`
+	Policies             types.List   `tfsdk:"policies"`
+	PoliciesCount        types.Int64  `tfsdk:"policies_count"`
+	PoliciesSize         types.Int64  `tfsdk:"policies_size"`
+	UserName             types.String `tfsdk:"user_name"`
+	GroupName            types.String `tfsdk:"group_name"`
+	RoleName             types.String `tfsdk:"role_name"`
+	PolicyQuota          types.Int64  `tfsdk:"policy_quota"`
+	AttachmentQuota      types.Int64  `tfsdk:"attachment_quota"`
+	PolicyHeadroom       types.Int64  `tfsdk:"policy_headroom"`
+	AttachmentHeadroom   types.Int64  `tfsdk:"attachment_headroom"`
 }
 
 type policyDetail struct {
@@ -48,6 +81,13 @@ type policyDetail struct {
 	PolicyDocument types.String `tfsdk:"policy_document"`
 }
 
+// sourcePolicy is the default version of a policy listed in attached_policies.
+type sourcePolicy struct {
+	PolicyName     string
+	PolicyType     string
+	PolicyDocument string
+}
+
 func (r *ramPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_ram_policy"
 }
@@ -61,6 +101,12 @@ func (r *ramPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Required:    true,
 				ElementType: types.StringType,
 			},
+			"attached_policies_hash": schema.MapAttribute{
+				Description: "The SHA-256 hash of each attached policy document, keyed by policy name. " +
+					"Used to detect changes made to the attached policies outside Terraform.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 			"policies": schema.ListNestedAttribute{
 				Description: "A list of policies.",
 				Computed:    true,
@@ -77,9 +123,60 @@ func (r *ramPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					},
 				},
 			},
+			"policies_count": schema.Int64Attribute{
+				Description: "The number of policies the attached policies are split into.",
+				Computed:    true,
+			},
+			"policies_size": schema.Int64Attribute{
+				Description: "The total character length of all the policy documents.",
+				Computed:    true,
+			},
 			"user_name": schema.StringAttribute{
-				Description: "The name of the RAM user that attached to the policy.",
-				Required:    true,
+				Description: "The name of the RAM user that attached to the policy. Exactly one of " +
+					"user_name, group_name or role_name must be set.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(
+						path.MatchRoot("group_name"),
+						path.MatchRoot("role_name"),
+					),
+				},
+			},
+			"group_name": schema.StringAttribute{
+				Description: "The name of the RAM group that attached to the policy.",
+				Optional:    true,
+			},
+			"role_name": schema.StringAttribute{
+				Description: "The name of the RAM role that attached to the policy.",
+				Optional:    true,
+			},
+			"policy_quota": schema.Int64Attribute{
+				Description: "The maximum number of custom policies in the account. Set it if the quota " +
+					"has been increased in Quota Center. Default to 1500.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Default: int64default.StaticInt64(defaultRamPolicyQuota),
+			},
+			"attachment_quota": schema.Int64Attribute{
+				Description: "The maximum number of custom policies that can be attached to the principal. " +
+					"Set it if the quota has been increased in Quota Center. Default to 5.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Default: int64default.StaticInt64(defaultRamAttachmentQuota),
+			},
+			"policy_headroom": schema.Int64Attribute{
+				Description: "The number of custom policies that can still be created in the account.",
+				Computed:    true,
+			},
+			"attachment_headroom": schema.Int64Attribute{
+				Description: "The number of custom policies that can still be attached to the principal.",
+				Computed:    true,
 			},
 		},
 	}
@@ -100,7 +197,7 @@ func (r *ramPolicyResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	policy, err := r.createPolicy(plan)
+	policyDocuments, policy, err := r.buildPolicy(plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"[API ERROR] Failed to Create the Policy.",
@@ -109,8 +206,17 @@ func (r *ramPolicyResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	if err := r.createPolicy(plan, policyDocuments); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Create the Policy.",
+			err.Error(),
+		)
+		return
+	}
+
 	state := &ramPolicyResourceModel{}
 	state.AttachedPolicies = plan.AttachedPolicies
+	state.AttachedPoliciesHash = plan.AttachedPoliciesHash
 	state.Policies = types.ListValueMust(
 		types.ObjectType{
 			AttrTypes: map[string]attr.Type{
@@ -120,11 +226,17 @@ func (r *ramPolicyResource) Create(ctx context.Context, req resource.CreateReque
 		},
 		policy,
 	)
+	state.PoliciesCount = plan.PoliciesCount
+	state.PoliciesSize = plan.PoliciesSize
 	state.UserName = plan.UserName
+	state.GroupName = plan.GroupName
+	state.RoleName = plan.RoleName
+	state.PolicyQuota = plan.PolicyQuota
+	state.AttachmentQuota = plan.AttachmentQuota
 
-	if err := r.attachPolicyToUser(state); err != nil {
+	if err := r.attachPolicy(state); err != nil {
 		resp.Diagnostics.AddError(
-			"[API ERROR] Failed to Attach Policy to User.",
+			"[API ERROR] Failed to Attach Policy.",
 			err.Error(),
 		)
 		return
@@ -136,6 +248,12 @@ func (r *ramPolicyResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	readHeadroomDiags := r.readPolicyHeadroom(state)
+	resp.Diagnostics.Append(readHeadroomDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setStateDiags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(setStateDiags...)
 	if resp.Diagnostics.HasError() {
@@ -157,28 +275,18 @@ func (r *ramPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	listPoliciesForUser := func() error {
-		runtime := &util.RuntimeOptions{}
-
-		listPoliciesForUserRequest := &alicloudRamClient.ListPoliciesForUserRequest{
-			UserName: tea.String(state.UserName.ValueString()),
-		}
-
-		_, err := r.client.ListPoliciesForUserWithOptions(listPoliciesForUserRequest, runtime)
-		if err != nil {
-			handleAPIError(err)
-		}
-		return nil
+	// The quotas are not set for the resources created or imported before
+	// they were introduced.
+	if state.PolicyQuota.IsNull() {
+		state.PolicyQuota = types.Int64Value(defaultRamPolicyQuota)
+	}
+	if state.AttachmentQuota.IsNull() {
+		state.AttachmentQuota = types.Int64Value(defaultRamAttachmentQuota)
 	}
 
-	reconnectBackoff := backoff.NewExponentialBackOff()
-	reconnectBackoff.MaxElapsedTime = 30 * time.Second
-	err := backoff.Retry(listPoliciesForUser, reconnectBackoff)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"[API ERROR] Failed to Read Users for Group",
-			err.Error(),
-		)
+	readHeadroomDiags := r.readPolicyHeadroom(state)
+	resp.Diagnostics.Append(readHeadroomDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -195,6 +303,25 @@ func (r *ramPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 		state.AttachedPolicies = types.ListNull(types.StringType)
 	}
 
+	// Re-fetch the attached policies to find out whether any of them has been
+	// modified since the combined policies were created. The update itself is
+	// planned in ModifyPlan, so only a warning is raised here.
+	if !(state.AttachedPolicies.IsNull() || state.AttachedPoliciesHash.IsNull()) {
+		sourcePolicies, err := r.getSourcePolicies(state.AttachedPolicies)
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Unable to Read Attached Policies.",
+				"Drift of the attached policies could not be checked: "+err.Error(),
+			)
+		} else if changedPolicies := getChangedSourcePolicies(state.AttachedPoliciesHash, sourcePolicies); len(changedPolicies) > 0 {
+			resp.Diagnostics.AddWarning(
+				"Attached Policies Changed Outside Terraform.",
+				fmt.Sprintf("The following attached policies have been modified since the combined policies were created: %s. "+
+					"The combined policies will be recreated on the next apply.", strings.Join(changedPolicies, ", ")),
+			)
+		}
+	}
+
 	setStateDiags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(setStateDiags...)
 	if resp.Diagnostics.HasError() {
@@ -216,14 +343,44 @@ func (r *ramPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	// Changing only the quotas does not require the policies to be recreated.
+	if plan.UserName.Equal(state.UserName) && plan.GroupName.Equal(state.GroupName) && plan.RoleName.Equal(state.RoleName) &&
+		plan.AttachedPolicies.Equal(state.AttachedPolicies) &&
+		plan.AttachedPoliciesHash.Equal(state.AttachedPoliciesHash) && plan.Policies.Equal(state.Policies) {
+		state.PolicyQuota = plan.PolicyQuota
+		state.AttachmentQuota = plan.AttachmentQuota
+
+		readHeadroomDiags := r.readPolicyHeadroom(state)
+		resp.Diagnostics.Append(readHeadroomDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		setStateDiags := resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(setStateDiags...)
+		return
+	}
+
+	// The new policies are built and checked against the plan before the old
+	// policies are removed, so the principal keeps its policies if the
+	// attached policies have changed since the plan. The old policies are
+	// removed before the new policies are created, as they share the names.
+	policyDocuments, policy, err := r.buildPolicy(plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update the Policy.",
+			err.Error(),
+		)
+		return
+	}
+
 	removePolicyDiags := r.removePolicy(state)
 	resp.Diagnostics.Append(removePolicyDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	policy, err := r.createPolicy(plan)
-	if err != nil {
+	if err := r.createPolicy(plan, policyDocuments); err != nil {
 		resp.Diagnostics.AddError(
 			"[API ERROR] Failed to Update the Policy.",
 			err.Error(),
@@ -232,6 +389,7 @@ func (r *ramPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	state.AttachedPolicies = plan.AttachedPolicies
+	state.AttachedPoliciesHash = plan.AttachedPoliciesHash
 	state.Policies = types.ListValueMust(
 		types.ObjectType{
 			AttrTypes: map[string]attr.Type{
@@ -241,11 +399,17 @@ func (r *ramPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 		},
 		policy,
 	)
+	state.PoliciesCount = plan.PoliciesCount
+	state.PoliciesSize = plan.PoliciesSize
 	state.UserName = plan.UserName
+	state.GroupName = plan.GroupName
+	state.RoleName = plan.RoleName
+	state.PolicyQuota = plan.PolicyQuota
+	state.AttachmentQuota = plan.AttachmentQuota
 
-	if err := r.attachPolicyToUser(state); err != nil {
+	if err := r.attachPolicy(state); err != nil {
 		resp.Diagnostics.AddError(
-			"[API ERROR] Failed to Attach Policy to User.",
+			"[API ERROR] Failed to Attach Policy.",
 			err.Error(),
 		)
 		return
@@ -257,6 +421,12 @@ func (r *ramPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	readHeadroomDiags := r.readPolicyHeadroom(state)
+	resp.Diagnostics.Append(readHeadroomDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setStateDiags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(setStateDiags...)
 	if resp.Diagnostics.HasError() {
@@ -280,6 +450,13 @@ func (r *ramPolicyResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *ramPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The combined policies of a principal can be imported with an ID such
+	// as user:<name>, group:<name> or role:<name>.
+	if principalType, principalName, ok := strings.Cut(req.ID, ":"); ok {
+		r.importPrincipalPolicies(ctx, principalType, principalName, resp)
+		return
+	}
+
 	policyDetailsState := []*policyDetail{}
 	getPolicyResponse := &alicloudRamClient.GetPolicyResponse{}
 	policyNames := strings.Split(req.ID, ",")
@@ -361,21 +538,335 @@ func (r *ramPolicyResource) ImportState(ctx context.Context, req resource.Import
 	}
 }
 
-func (r *ramPolicyResource) createPolicy(plan *ramPolicyResourceModel) (policiesList []attr.Value, err error) {
-	formattedPolicy, err := r.getPolicyDocument(plan)
+// importPrincipalPolicies discovers the combined policies attached to the
+// principal by their <name>-N names, and restores attached_policies from the
+// description of the first combined policy.
+func (r *ramPolicyResource) importPrincipalPolicies(ctx context.Context, principalType, principalName string, resp *resource.ImportStateResponse) {
+	if !(principalType == "user" || principalType == "group" || principalType == "role") || principalName == "" {
+		resp.Diagnostics.AddError(
+			"[ERROR] Invalid Import ID.",
+			"The import ID must be either a comma-separated list of policy names, or one of "+
+				"user:<name>, group:<name> or role:<name>, got: "+principalType+":"+principalName,
+		)
+		return
+	}
+
+	principalPolicies, err := r.listPoliciesForPrincipal(principalType, principalName)
 	if err != nil {
-		return nil, err
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to List Policies.",
+			err.Error(),
+		)
+		return
 	}
 
+	policyNameRegex := regexp.MustCompile("^" + regexp.QuoteMeta(principalName) + `-([0-9]+)$`)
+	policyIndexes := make(map[string]int)
+	policyNames := make([]string, 0)
+	for policyName, policyType := range principalPolicies {
+		match := policyNameRegex.FindStringSubmatch(policyName)
+		if policyType != "Custom" || match == nil {
+			continue
+		}
+		policyIndexes[policyName], _ = strconv.Atoi(match[1])
+		policyNames = append(policyNames, policyName)
+	}
+	if len(policyNames) == 0 {
+		resp.Diagnostics.AddError(
+			"[ERROR] Combined Policies Not Found.",
+			fmt.Sprintf("No custom policy named %s-N is attached to the %s %s.", principalName, principalType, principalName),
+		)
+		return
+	}
+	sort.Slice(policyNames, func(i, j int) bool {
+		return policyIndexes[policyNames[i]] < policyIndexes[policyNames[j]]
+	})
+
+	policyList := make([]policyDetail, 0)
+	var attachedPolicies []string
+	for i, policyName := range policyNames {
+		var getPolicyResponse *alicloudRamClient.GetPolicyResponse
+		getPolicy := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			getPolicyRequest := &alicloudRamClient.GetPolicyRequest{
+				PolicyName: tea.String(policyName),
+				PolicyType: tea.String("Custom"),
+			}
+
+			var err error
+			getPolicyResponse, err = r.client.GetPolicyWithOptions(getPolicyRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(getPolicy, reconnectBackoff); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Policy.",
+				err.Error(),
+			)
+			return
+		}
+
+		if getPolicyResponse.Body.Policy == nil || getPolicyResponse.Body.DefaultPolicyVersion == nil {
+			continue
+		}
+		policyList = append(policyList, policyDetail{
+			PolicyName:     types.StringValue(policyName),
+			PolicyDocument: types.StringValue(tea.StringValue(getPolicyResponse.Body.DefaultPolicyVersion.PolicyDocument)),
+		})
+		if i == 0 {
+			attachedPolicies = parsePolicyDescription(tea.StringValue(getPolicyResponse.Body.Policy.Description))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(principalType+"_name"), principalName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policies"), policyList)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if attachedPolicies == nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Set the attached_policies Attribute",
+			"The combined policies were not created by this provider, so the attached policies could not be "+
+				"restored from their description. Run terraform apply to recreate the combined policies from "+
+				"the attached_policies in the Terraform configuration.",
+		)
+		return
+	}
+
+	attachedPoliciesList := types.ListValueMust(types.StringType, func() []attr.Value {
+		policies := make([]attr.Value, 0, len(attachedPolicies))
+		for _, policyName := range attachedPolicies {
+			policies = append(policies, types.StringValue(policyName))
+		}
+		return policies
+	}())
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("attached_policies"), attachedPoliciesList)...)
+
+	// Without the hash, the combined policies would be recreated on the next
+	// apply even though nothing has changed.
+	sourcePolicies, err := r.getSourcePolicies(attachedPoliciesList)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to Read Attached Policies.",
+			"The combined policies will be recreated on the next apply: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("attached_policies_hash"), getSourcePoliciesHash(sourcePolicies))...)
+}
+
+func (r *ramPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If the entire plan is null, the resource is planned for destruction.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *ramPolicyResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The attached policies can only be fetched once all of the names are known.
+	if plan.AttachedPolicies.IsUnknown() {
+		return
+	}
+	for _, policy := range plan.AttachedPolicies.Elements() {
+		if policy.IsUnknown() {
+			return
+		}
+	}
+
+	sourcePolicies, err := r.getSourcePolicies(plan.AttachedPolicies)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Attached Policies.",
+			err.Error(),
+		)
+		return
+	}
+	plan.AttachedPoliciesHash = getSourcePoliciesHash(sourcePolicies)
+
+	// Split the attached policies during plan, so that the exact policy
+	// documents to be attached to the user can be reviewed before apply.
+	formattedPolicy, err := getPolicyDocument(sourcePolicies)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[ERROR] Failed to Combine Attached Policies.",
+			err.Error(),
+		)
+		return
+	}
+
+	policiesSize := 0
+	for i, policy := range formattedPolicy {
+		policiesSize += len(policy)
+
+		// The combined documents come from the system and custom policies
+		// which RAM has already accepted, so the lint findings are only
+		// reported as warnings rather than failing the plan.
+		lintErrors, lintWarnings := lintRamPolicyDocument(policy)
+		for _, lintError := range lintErrors {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("policies").AtListIndex(i),
+				"Possibly Invalid RAM Policy Document",
+				lintError,
+			)
+		}
+		for _, lintWarning := range lintWarnings {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("policies").AtListIndex(i),
+				"Overly Permissive RAM Policy Document",
+				lintWarning,
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.PoliciesCount = types.Int64Value(int64(len(formattedPolicy)))
+	plan.PoliciesSize = types.Int64Value(int64(policiesSize))
+
+	// Check the quotas before anything is created, a split policy that is
+	// only partially created is hard to clean up.
+	principalType, principalName := plan.principal()
+	if !(principalName.IsUnknown() || plan.PolicyQuota.IsUnknown() || plan.AttachmentQuota.IsUnknown()) {
+		var state *ramPolicyResourceModel
+		getStateDiags := req.State.Get(ctx, &state)
+		resp.Diagnostics.Append(getStateDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		policyCount, attachmentCount, err := r.getPolicyUsage(principalType, principalName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Policy Usage.",
+				err.Error(),
+			)
+			return
+		}
+
+		// The policies of this resource are deleted before the new ones are
+		// created, so they do not count towards the quotas.
+		if state != nil {
+			policyCount -= len(state.Policies.Elements())
+			if statePrincipalType, statePrincipalName := state.principal(); statePrincipalType == principalType &&
+				statePrincipalName.Equal(principalName) {
+				attachmentCount -= len(state.Policies.Elements())
+			}
+		}
+		policyCount += len(formattedPolicy)
+		attachmentCount += len(formattedPolicy)
+
+		if int64(policyCount) > plan.PolicyQuota.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("policy_quota"),
+				"RAM Policy Quota Exceeded",
+				fmt.Sprintf("The account would have %d custom policies after apply, which exceeds the quota of %d. "+
+					"Delete unused custom policies, or request a quota increase in Quota Center and update policy_quota.",
+					policyCount, plan.PolicyQuota.ValueInt64()),
+			)
+		}
+		if int64(attachmentCount) > plan.AttachmentQuota.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("attachment_quota"),
+				"RAM Policy Attachment Quota Exceeded",
+				fmt.Sprintf("The %s %s would have %d custom policies attached after apply, which exceeds the quota of %d. "+
+					"Reduce the attached policies, or request a quota increase in Quota Center and update attachment_quota.",
+					principalType, principalName.ValueString(), attachmentCount, plan.AttachmentQuota.ValueInt64()),
+			)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The policy names are derived from the principal name.
+	if principalName.IsUnknown() {
+		plan.Policies = types.ListUnknown(
+			types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"policy_name":     types.StringType,
+					"policy_document": types.StringType,
+				},
+			},
+		)
+	} else {
+		plan.Policies = types.ListValueMust(
+			types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"policy_name":     types.StringType,
+					"policy_document": types.StringType,
+				},
+			},
+			getPolicyDetails(principalName.ValueString(), formattedPolicy),
+		)
+	}
+
+	setPlanDiags := resp.Plan.Set(ctx, &plan)
+	resp.Diagnostics.Append(setPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// buildPolicy combines the attached policies into the documents of the
+// combined policies, without creating them.
+func (r *ramPolicyResource) buildPolicy(plan *ramPolicyResourceModel) (policyDocuments []string, policiesList []attr.Value, err error) {
+	sourcePolicies, err := r.getSourcePolicies(plan.AttachedPolicies)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policyDocuments, err = getPolicyDocument(sourcePolicies)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, principalName := plan.principal()
+	policiesList = getPolicyDetails(principalName.ValueString(), policyDocuments)
+
+	// The policy documents are computed and reviewed during plan, make sure
+	// the attached policies have not been changed since then.
+	if !(plan.Policies.IsNull() || plan.Policies.IsUnknown()) {
+		if len(plan.Policies.Elements()) != len(policiesList) {
+			return nil, nil, fmt.Errorf("the attached policies have been changed after the plan was created, please run terraform plan again")
+		}
+		for i, policy := range plan.Policies.Elements() {
+			if !policy.Equal(policiesList[i]) {
+				return nil, nil, fmt.Errorf("the attached policies have been changed after the plan was created, please run terraform plan again")
+			}
+		}
+	}
+
+	return policyDocuments, policiesList, nil
+}
+
+// createPolicy creates the combined policies of the documents built by
+// buildPolicy.
+func (r *ramPolicyResource) createPolicy(plan *ramPolicyResourceModel, policyDocuments []string) error {
+	_, principalName := plan.principal()
+	description := getPolicyDescription(plan.AttachedPolicies)
+
 	createPolicy := func() error {
 		runtime := &util.RuntimeOptions{}
 
-		for i, policy := range formattedPolicy {
-			policyName := plan.UserName.ValueString() + "-" + strconv.Itoa(i+1)
+		for i, policy := range policyDocuments {
+			policyName := principalName.ValueString() + "-" + strconv.Itoa(i+1)
 
 			createPolicyRequest := &alicloudRamClient.CreatePolicyRequest{
 				PolicyName:     tea.String(policyName),
 				PolicyDocument: tea.String(policy),
+				Description:    description,
 			}
 
 			if _, err := r.client.CreatePolicyWithOptions(createPolicyRequest, runtime); err != nil {
@@ -386,8 +877,42 @@ func (r *ramPolicyResource) createPolicy(plan *ramPolicyResourceModel) (policies
 		return nil
 	}
 
-	for i, policies := range formattedPolicy {
-		policyName := plan.UserName.ValueString() + "-" + strconv.Itoa(i+1)
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(createPolicy, reconnectBackoff)
+}
+
+// getPolicyDescription records the names of the attached policies in the
+// description of the combined policies, so that attached_policies can be
+// restored on import. Nothing is recorded if the names do not fit.
+func getPolicyDescription(attachedPolicies types.List) *string {
+	policyNames := make([]string, 0)
+	for _, policy := range attachedPolicies.Elements() {
+		policyNames = append(policyNames, trimStringQuotes(policy.String()))
+	}
+
+	description := ramPolicyDescriptionPrefix + strings.Join(policyNames, ",")
+	if len(description) > ramPolicyDescriptionMaxLength {
+		return nil
+	}
+	return tea.String(description)
+}
+
+// parsePolicyDescription returns the names of the attached policies recorded
+// by getPolicyDescription, or nil if the description was not written by it.
+func parsePolicyDescription(description string) []string {
+	if !strings.HasPrefix(description, ramPolicyDescriptionPrefix) || description == ramPolicyDescriptionPrefix {
+		return nil
+	}
+	return strings.Split(strings.TrimPrefix(description, ramPolicyDescriptionPrefix), ",")
+}
+
+// getPolicyDetails names the policy documents after the principal and converts
+// them into the objects of the policies attribute.
+func getPolicyDetails(principalName string, policyDocuments []string) (policiesList []attr.Value) {
+	policiesList = make([]attr.Value, 0)
+	for i, policies := range policyDocuments {
+		policyName := principalName + "-" + strconv.Itoa(i+1)
 
 		policyObj := types.ObjectValueMust(
 			map[string]attr.Type{
@@ -402,9 +927,7 @@ func (r *ramPolicyResource) createPolicy(plan *ramPolicyResourceModel) (policies
 		policiesList = append(policiesList, policyObj)
 	}
 
-	reconnectBackoff := backoff.NewExponentialBackOff()
-	reconnectBackoff.MaxElapsedTime = 30 * time.Second
-	return policiesList, backoff.Retry(createPolicy, reconnectBackoff)
+	return policiesList
 }
 
 func (r *ramPolicyResource) readPolicy(state *ramPolicyResourceModel) diag.Diagnostics {
@@ -456,8 +979,10 @@ func (r *ramPolicyResource) readPolicy(state *ramPolicyResourceModel) diag.Diagn
 		}
 	}
 
+	policiesSize := 0
 	policyDetails := []attr.Value{}
 	for _, policy := range policyDetailsState {
+		policiesSize += len(policy.PolicyDocument.ValueString())
 		policyDetails = append(policyDetails, types.ObjectValueMust(
 			map[string]attr.Type{
 				"policy_name":     types.StringType,
@@ -478,6 +1003,8 @@ func (r *ramPolicyResource) readPolicy(state *ramPolicyResourceModel) diag.Diagn
 		},
 		policyDetails,
 	)
+	state.PoliciesCount = types.Int64Value(int64(len(policyDetails)))
+	state.PoliciesSize = types.Int64Value(int64(policiesSize))
 	return nil
 }
 
@@ -490,17 +1017,35 @@ func (r *ramPolicyResource) removePolicy(state *ramPolicyResourceModel) diag.Dia
 
 			json.Unmarshal([]byte(policies.String()), &data)
 
-			detachPolicyFromUserRequest := &alicloudRamClient.DetachPolicyFromUserRequest{
-				PolicyType: tea.String("Custom"),
-				PolicyName: tea.String(data["policy_name"]),
-				UserName:   tea.String(state.UserName.ValueString()),
-			}
-
 			deletePolicyRequest := &alicloudRamClient.DeletePolicyRequest{
 				PolicyName: tea.String(data["policy_name"]),
 			}
 
-			if _, err := r.client.DetachPolicyFromUserWithOptions(detachPolicyFromUserRequest, runtime); err != nil {
+			var err error
+			switch principalType, principalName := state.principal(); principalType {
+			case "group":
+				detachPolicyFromGroupRequest := &alicloudRamClient.DetachPolicyFromGroupRequest{
+					PolicyType: tea.String("Custom"),
+					PolicyName: tea.String(data["policy_name"]),
+					GroupName:  tea.String(principalName.ValueString()),
+				}
+				_, err = r.client.DetachPolicyFromGroupWithOptions(detachPolicyFromGroupRequest, runtime)
+			case "role":
+				detachPolicyFromRoleRequest := &alicloudRamClient.DetachPolicyFromRoleRequest{
+					PolicyType: tea.String("Custom"),
+					PolicyName: tea.String(data["policy_name"]),
+					RoleName:   tea.String(principalName.ValueString()),
+				}
+				_, err = r.client.DetachPolicyFromRoleWithOptions(detachPolicyFromRoleRequest, runtime)
+			default:
+				detachPolicyFromUserRequest := &alicloudRamClient.DetachPolicyFromUserRequest{
+					PolicyType: tea.String("Custom"),
+					PolicyName: tea.String(data["policy_name"]),
+					UserName:   tea.String(principalName.ValueString()),
+				}
+				_, err = r.client.DetachPolicyFromUserWithOptions(detachPolicyFromUserRequest, runtime)
+			}
+			if err != nil {
 				handleAPIError(err)
 			}
 
@@ -527,22 +1072,20 @@ func (r *ramPolicyResource) removePolicy(state *ramPolicyResourceModel) diag.Dia
 	return nil
 }
 
-func (r *ramPolicyResource) getPolicyDocument(plan *ramPolicyResourceModel) (finalPolicyDocument []string, err error) {
-	policyName := ""
-	currentLength := 0
-	currentPolicyDocument := ""
-	appendedPolicyDocument := make([]string, 0)
-	finalPolicyDocument = make([]string, 0)
-
-	var getPolicyResponse *alicloudRamClient.GetPolicyResponse
+// getSourcePolicies retrieves the default version of every policy in
+// attached_policies. The policy is looked up as a Custom policy first, then
+// as a System policy.
+func (r *ramPolicyResource) getSourcePolicies(attachedPolicies types.List) ([]*sourcePolicy, error) {
+	sourcePolicies := make([]*sourcePolicy, 0)
 
-	for i, policy := range plan.AttachedPolicies.Elements() {
-		policyName = policy.String()
+	for _, policy := range attachedPolicies.Elements() {
+		policyName := trimStringQuotes(policy.String())
 		getPolicyRequest := &alicloudRamClient.GetPolicyRequest{
 			PolicyType: tea.String("Custom"),
-			PolicyName: tea.String(trimStringQuotes(policyName)),
+			PolicyName: tea.String(policyName),
 		}
 
+		var getPolicyResponse *alicloudRamClient.GetPolicyResponse
 		getPolicy := func() error {
 			runtime := &util.RuntimeOptions{}
 			for {
@@ -572,54 +1115,106 @@ func (r *ramPolicyResource) getPolicyDocument(plan *ramPolicyResourceModel) (fin
 		reconnectBackoff.MaxElapsedTime = 30 * time.Second
 		backoff.Retry(getPolicy, reconnectBackoff)
 
-		if getPolicyResponse.Body != nil && getPolicyResponse.Body.DefaultPolicyVersion != nil {
-			if getPolicyResponse.Body.DefaultPolicyVersion.PolicyDocument != nil {
-				tempPolicyDocument := *getPolicyResponse.Body.DefaultPolicyVersion.PolicyDocument
+		if getPolicyResponse == nil || getPolicyResponse.Body == nil || getPolicyResponse.Body.DefaultPolicyVersion == nil ||
+			getPolicyResponse.Body.DefaultPolicyVersion.PolicyDocument == nil {
+			return nil, fmt.Errorf("could not find the policy: %v", policyName)
+		}
 
-				var data map[string]interface{}
-				if err := json.Unmarshal([]byte(tempPolicyDocument), &data); err != nil {
-					return nil, err
-				}
+		sourcePolicies = append(sourcePolicies, &sourcePolicy{
+			PolicyName:     policyName,
+			PolicyType:     *getPolicyRequest.PolicyType,
+			PolicyDocument: *getPolicyResponse.Body.DefaultPolicyVersion.PolicyDocument,
+		})
+	}
 
-				statementArr := data["Statement"].([]interface{})
-				statementBytes, err := json.MarshalIndent(statementArr, "", "  ")
-				if err != nil {
-					return nil, err
-				}
+	return sourcePolicies, nil
+}
 
-				removeSpaces := strings.ReplaceAll(string(statementBytes), " ", "")
-				replacer := strings.NewReplacer("\n", "")
-				removeParagraphs := replacer.Replace(removeSpaces)
+// getSourcePoliciesHash returns the SHA-256 hash of every source policy
+// document, keyed by policy name. The documents are compacted before hashing
+// so that formatting changes returned by the API are not treated as changes.
+func getSourcePoliciesHash(sourcePolicies []*sourcePolicy) types.Map {
+	hashes := make(map[string]attr.Value)
+	for _, policy := range sourcePolicies {
+		document := new(bytes.Buffer)
+		if err := json.Compact(document, []byte(policy.PolicyDocument)); err != nil {
+			document.Reset()
+			document.WriteString(policy.PolicyDocument)
+		}
 
-				finalStatement := strings.Trim(removeParagraphs, "[]")
+		sum := sha256.Sum256(document.Bytes())
+		hashes[policy.PolicyName] = types.StringValue(hex.EncodeToString(sum[:]))
+	}
 
-				currentLength += len(finalStatement)
+	return types.MapValueMust(types.StringType, hashes)
+}
 
-				// Before further proceeding the current policy, we need to add a number of 30 to simulate the total length of completed policy to check whether it is already execeeded the max character length of 6144.
-				// Number of 30 indicates the character length of neccessary policy keyword such as "Version" and "Statement" and some JSON symbols ({}, [])
-				if (currentLength + 30) > maxLength {
-					lastCommaIndex := strings.LastIndex(currentPolicyDocument, ",")
-					if lastCommaIndex >= 0 {
-						currentPolicyDocument = currentPolicyDocument[:lastCommaIndex] + currentPolicyDocument[lastCommaIndex+1:]
-					}
+// getChangedSourcePolicies returns the names of the source policies whose
+// hash differs from the hash recorded in state.
+func getChangedSourcePolicies(stateHash types.Map, sourcePolicies []*sourcePolicy) []string {
+	changedPolicies := make([]string, 0)
+	currentHash := getSourcePoliciesHash(sourcePolicies).Elements()
+	for _, policy := range sourcePolicies {
+		previousHash, ok := stateHash.Elements()[policy.PolicyName]
+		if !ok || !previousHash.Equal(currentHash[policy.PolicyName]) {
+			changedPolicies = append(changedPolicies, policy.PolicyName)
+		}
+	}
 
-					appendedPolicyDocument = append(appendedPolicyDocument, currentPolicyDocument)
-					currentPolicyDocument = finalStatement + ","
-					currentLength = len(finalStatement)
-				} else {
-					currentPolicyDocument += finalStatement + ","
-				}
+	return changedPolicies
+}
 
-				if i == len(plan.AttachedPolicies.Elements())-1 && (currentLength+30) <= maxLength {
-					lastCommaIndex := strings.LastIndex(currentPolicyDocument, ",")
-					if lastCommaIndex >= 0 {
-						currentPolicyDocument = currentPolicyDocument[:lastCommaIndex] + currentPolicyDocument[lastCommaIndex+1:]
-					}
-					appendedPolicyDocument = append(appendedPolicyDocument, currentPolicyDocument)
-				}
+// getPolicyDocument combines the statements of the source policies and splits
+// them into policy documents that fit within the max character length.
+func getPolicyDocument(sourcePolicies []*sourcePolicy) (finalPolicyDocument []string, err error) {
+	currentLength := 0
+	currentPolicyDocument := ""
+	appendedPolicyDocument := make([]string, 0)
+	finalPolicyDocument = make([]string, 0)
+
+	for i, policy := range sourcePolicies {
+		tempPolicyDocument := policy.PolicyDocument
+
+		var data map[string]interface{}
+		if err := json.Unmarshal([]byte(tempPolicyDocument), &data); err != nil {
+			return nil, err
+		}
+
+		statementArr := data["Statement"].([]interface{})
+		statementBytes, err := json.MarshalIndent(statementArr, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		removeSpaces := strings.ReplaceAll(string(statementBytes), " ", "")
+		replacer := strings.NewReplacer("\n", "")
+		removeParagraphs := replacer.Replace(removeSpaces)
+
+		finalStatement := strings.Trim(removeParagraphs, "[]")
+
+		currentLength += len(finalStatement)
+
+		// Before further proceeding the current policy, we need to add a number of 30 to simulate the total length of completed policy to check whether it is already execeeded the max character length of 6144.
+		// Number of 30 indicates the character length of neccessary policy keyword such as "Version" and "Statement" and some JSON symbols ({}, [])
+		if (currentLength + 30) > maxLength {
+			lastCommaIndex := strings.LastIndex(currentPolicyDocument, ",")
+			if lastCommaIndex >= 0 {
+				currentPolicyDocument = currentPolicyDocument[:lastCommaIndex] + currentPolicyDocument[lastCommaIndex+1:]
 			}
+
+			appendedPolicyDocument = append(appendedPolicyDocument, currentPolicyDocument)
+			currentPolicyDocument = finalStatement + ","
+			currentLength = len(finalStatement)
 		} else {
-			return nil, fmt.Errorf("could not find the policy: %v", policyName)
+			currentPolicyDocument += finalStatement + ","
+		}
+
+		if i == len(sourcePolicies)-1 && (currentLength+30) <= maxLength {
+			lastCommaIndex := strings.LastIndex(currentPolicyDocument, ",")
+			if lastCommaIndex >= 0 {
+				currentPolicyDocument = currentPolicyDocument[:lastCommaIndex] + currentPolicyDocument[lastCommaIndex+1:]
+			}
+			appendedPolicyDocument = append(appendedPolicyDocument, currentPolicyDocument)
 		}
 	}
 
@@ -632,22 +1227,185 @@ func (r *ramPolicyResource) getPolicyDocument(plan *ramPolicyResourceModel) (fin
 	return finalPolicyDocument, nil
 }
 
-func (r *ramPolicyResource) attachPolicyToUser(state *ramPolicyResourceModel) (err error) {
+func (r *ramPolicyResource) attachPolicy(state *ramPolicyResourceModel) (err error) {
 	data := make(map[string]string)
+	principalType, principalName := state.principal()
 
-	attachPolicyToUser := func() error {
+	attachPolicy := func() error {
 		for _, policies := range state.Policies.Elements() {
 			json.Unmarshal([]byte(policies.String()), &data)
 
-			attachPolicyToUserRequest := &alicloudRamClient.AttachPolicyToUserRequest{
-				PolicyType: tea.String("Custom"),
-				PolicyName: tea.String(data["policy_name"]),
-				UserName:   tea.String(state.UserName.ValueString()),
+			runtime := &util.RuntimeOptions{}
+			var err error
+			switch principalType {
+			case "group":
+				attachPolicyToGroupRequest := &alicloudRamClient.AttachPolicyToGroupRequest{
+					PolicyType: tea.String("Custom"),
+					PolicyName: tea.String(data["policy_name"]),
+					GroupName:  tea.String(principalName.ValueString()),
+				}
+				_, err = r.client.AttachPolicyToGroupWithOptions(attachPolicyToGroupRequest, runtime)
+			case "role":
+				attachPolicyToRoleRequest := &alicloudRamClient.AttachPolicyToRoleRequest{
+					PolicyType: tea.String("Custom"),
+					PolicyName: tea.String(data["policy_name"]),
+					RoleName:   tea.String(principalName.ValueString()),
+				}
+				_, err = r.client.AttachPolicyToRoleWithOptions(attachPolicyToRoleRequest, runtime)
+			default:
+				attachPolicyToUserRequest := &alicloudRamClient.AttachPolicyToUserRequest{
+					PolicyType: tea.String("Custom"),
+					PolicyName: tea.String(data["policy_name"]),
+					UserName:   tea.String(principalName.ValueString()),
+				}
+				_, err = r.client.AttachPolicyToUserWithOptions(attachPolicyToUserRequest, runtime)
 			}
+			if err != nil {
+				handleAPIError(err)
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(attachPolicy, reconnectBackoff)
+}
+
+// principal returns the type and the name of the RAM identity the combined
+// policies are attached to.
+func (m *ramPolicyResourceModel) principal() (principalType string, principalName types.String) {
+	switch {
+	case !m.GroupName.IsNull():
+		return "group", m.GroupName
+	case !m.RoleName.IsNull():
+		return "role", m.RoleName
+	default:
+		return "user", m.UserName
+	}
+}
+
+// readPolicyHeadroom sets the number of custom policies that can still be
+// created in the account and attached to the principal.
+func (r *ramPolicyResource) readPolicyHeadroom(state *ramPolicyResourceModel) diag.Diagnostics {
+	policyCount, attachmentCount, err := r.getPolicyUsage(state.principal())
+	if err != nil {
+		return diag.Diagnostics{
+			diag.NewErrorDiagnostic(
+				"[API ERROR] Failed to Read Policy Usage.",
+				err.Error(),
+			),
+		}
+	}
 
+	state.PolicyHeadroom = types.Int64Value(state.PolicyQuota.ValueInt64() - int64(policyCount))
+	state.AttachmentHeadroom = types.Int64Value(state.AttachmentQuota.ValueInt64() - int64(attachmentCount))
+	return nil
+}
+
+// getPolicyUsage counts the custom policies in the account and the custom
+// policies attached to the principal.
+func (r *ramPolicyResource) getPolicyUsage(principalType string, principalName types.String) (policyCount int, attachmentCount int, err error) {
+	var marker *string
+	for {
+		var listPoliciesResponse *alicloudRamClient.ListPoliciesResponse
+		listPolicies := func() error {
 			runtime := &util.RuntimeOptions{}
-			if _, err := r.client.AttachPolicyToUserWithOptions(attachPolicyToUserRequest, runtime); err != nil {
-				handleAPIError(err)
+
+			listPoliciesRequest := &alicloudRamClient.ListPoliciesRequest{
+				PolicyType: tea.String("Custom"),
+				Marker:     marker,
+				MaxItems:   tea.Int32(1000),
+			}
+
+			var err error
+			listPoliciesResponse, err = r.client.ListPoliciesWithOptions(listPoliciesRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(listPolicies, reconnectBackoff); err != nil {
+			return 0, 0, err
+		}
+
+		if listPoliciesResponse.Body.Policies != nil {
+			policyCount += len(listPoliciesResponse.Body.Policies.Policy)
+		}
+
+		if !tea.BoolValue(listPoliciesResponse.Body.IsTruncated) {
+			break
+		}
+		marker = listPoliciesResponse.Body.Marker
+	}
+
+	principalPolicies, err := r.listPoliciesForPrincipal(principalType, principalName.ValueString())
+	if err != nil {
+		// The principal may only be created after plan.
+		if _t, ok := err.(*tea.SDKError); ok && strings.HasPrefix(tea.StringValue(_t.Code), "EntityNotExist.") {
+			return policyCount, 0, nil
+		}
+		return 0, 0, err
+	}
+	for _, policyType := range principalPolicies {
+		if policyType == "Custom" {
+			attachmentCount++
+		}
+	}
+
+	return policyCount, attachmentCount, nil
+}
+
+// listPoliciesForPrincipal returns the type of every policy attached to the
+// principal, keyed by policy name.
+func (r *ramPolicyResource) listPoliciesForPrincipal(principalType, principalName string) (map[string]string, error) {
+	policies := make(map[string]string)
+
+	listPoliciesForPrincipal := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		switch principalType {
+		case "group":
+			listPoliciesForGroupRequest := &alicloudRamClient.ListPoliciesForGroupRequest{
+				GroupName: tea.String(principalName),
+			}
+			listPoliciesForGroupResponse, err := r.client.ListPoliciesForGroupWithOptions(listPoliciesForGroupRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			if listPoliciesForGroupResponse.Body.Policies != nil {
+				for _, policy := range listPoliciesForGroupResponse.Body.Policies.Policy {
+					policies[tea.StringValue(policy.PolicyName)] = tea.StringValue(policy.PolicyType)
+				}
+			}
+		case "role":
+			listPoliciesForRoleRequest := &alicloudRamClient.ListPoliciesForRoleRequest{
+				RoleName: tea.String(principalName),
+			}
+			listPoliciesForRoleResponse, err := r.client.ListPoliciesForRoleWithOptions(listPoliciesForRoleRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			if listPoliciesForRoleResponse.Body.Policies != nil {
+				for _, policy := range listPoliciesForRoleResponse.Body.Policies.Policy {
+					policies[tea.StringValue(policy.PolicyName)] = tea.StringValue(policy.PolicyType)
+				}
+			}
+		default:
+			listPoliciesForUserRequest := &alicloudRamClient.ListPoliciesForUserRequest{
+				UserName: tea.String(principalName),
+			}
+			listPoliciesForUserResponse, err := r.client.ListPoliciesForUserWithOptions(listPoliciesForUserRequest, runtime)
+			if err != nil {
+				return handleAPIError(err)
+			}
+			if listPoliciesForUserResponse.Body.Policies != nil {
+				for _, policy := range listPoliciesForUserResponse.Body.Policies.Policy {
+					policies[tea.StringValue(policy.PolicyName)] = tea.StringValue(policy.PolicyType)
+				}
 			}
 		}
 		return nil
@@ -655,7 +1413,11 @@ func (r *ramPolicyResource) attachPolicyToUser(state *ramPolicyResourceModel) (e
 
 	reconnectBackoff := backoff.NewExponentialBackOff()
 	reconnectBackoff.MaxElapsedTime = 30 * time.Second
-	return backoff.Retry(attachPolicyToUser, reconnectBackoff)
+	if err := backoff.Retry(listPoliciesForPrincipal, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
 }
 
 func handleAPIError(err error) error {
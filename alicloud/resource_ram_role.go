@@ -0,0 +1,613 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &ramRoleResource{}
+	_ resource.ResourceWithConfigure   = &ramRoleResource{}
+	_ resource.ResourceWithImportState = &ramRoleResource{}
+)
+
+func NewRamRoleResource() resource.Resource {
+	return &ramRoleResource{}
+}
+
+type ramRoleResource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramRoleResourceModel struct {
+	RoleName                 types.String `tfsdk:"role_name"`
+	AssumeRolePolicyDocument types.String `tfsdk:"assume_role_policy_document"`
+	MaxSessionDuration       types.Int64  `tfsdk:"max_session_duration"`
+	Description              types.String `tfsdk:"description"`
+	ManagedPolicies          types.List   `tfsdk:"managed_policies"`
+	RoleId                   types.String `tfsdk:"role_id"`
+	Arn                      types.String `tfsdk:"arn"`
+}
+
+type ramRoleManagedPolicy struct {
+	PolicyName types.String `tfsdk:"policy_name"`
+	PolicyType types.String `tfsdk:"policy_type"`
+}
+
+func (r *ramRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_role"
+}
+
+func (r *ramRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a RAM Role resource. The managed policies are attached non-exclusively, " +
+			"policies attached to the role outside Terraform are not touched.",
+		Attributes: map[string]schema.Attribute{
+			"role_name": schema.StringAttribute{
+				Description: "The name of the RAM role.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 64),
+				},
+			},
+			"assume_role_policy_document": schema.StringAttribute{
+				Description: "The trust policy that specifies the principals which are allowed to assume the role.",
+				Required:    true,
+				Validators: []validator.String{
+					validRamPolicyDocument(),
+				},
+			},
+			"max_session_duration": schema.Int64Attribute{
+				Description: "The maximum session duration of the role in seconds. " +
+					"Valid values: 3600 to 43200. Default to 3600.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(3600, 43200),
+				},
+				Default: int64default.StaticInt64(3600),
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the RAM role.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(1024),
+				},
+			},
+			"managed_policies": schema.ListNestedAttribute{
+				Description: "A list of system and custom policies to attach to the role. " +
+					"Policies attached outside Terraform are not detached.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"policy_name": schema.StringAttribute{
+							Description: "The name of the policy.",
+							Required:    true,
+						},
+						"policy_type": schema.StringAttribute{
+							Description: "The type of the policy. Valid values: System, Custom.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("System", "Custom"),
+							},
+						},
+					},
+				},
+			},
+			"role_id": schema.StringAttribute{
+				Description: "The ID of the RAM role.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"arn": schema.StringAttribute{
+				Description: "The ARN of the RAM role.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ramRoleResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (r *ramRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ramRoleResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createRoleRequest := &alicloudRamClient.CreateRoleRequest{
+		RoleName:                 tea.String(plan.RoleName.ValueString()),
+		AssumeRolePolicyDocument: tea.String(plan.AssumeRolePolicyDocument.ValueString()),
+		MaxSessionDuration:       tea.Int64(plan.MaxSessionDuration.ValueInt64()),
+	}
+	if !(plan.Description.IsNull() || plan.Description.IsUnknown()) {
+		createRoleRequest.Description = tea.String(plan.Description.ValueString())
+	}
+
+	var createRoleResponse *alicloudRamClient.CreateRoleResponse
+	createRole := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		var err error
+		createRoleResponse, err = r.client.CreateRoleWithOptions(createRoleRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(createRole, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Create RAM Role.",
+			err.Error(),
+		)
+		return
+	}
+
+	state := &ramRoleResourceModel{}
+	state.RoleName = plan.RoleName
+	state.AssumeRolePolicyDocument = plan.AssumeRolePolicyDocument
+	state.MaxSessionDuration = plan.MaxSessionDuration
+	state.Description = plan.Description
+	state.ManagedPolicies = plan.ManagedPolicies
+	state.RoleId = types.StringValue(tea.StringValue(createRoleResponse.Body.Role.RoleId))
+	state.Arn = types.StringValue(tea.StringValue(createRoleResponse.Body.Role.Arn))
+
+	// The role is saved into state before attaching policies, so that it is
+	// not orphaned if any of the policies fails to attach.
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	managedPolicies, diags := getRamRoleManagedPolicies(ctx, plan.ManagedPolicies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.attachPoliciesToRole(plan.RoleName.ValueString(), managedPolicies); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Attach Policy to Role.",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ramRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ramRoleResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.getRole(state.RoleName.ValueString())
+	if err != nil {
+		if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "EntityNotExist.Role" {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read RAM Role",
+			err.Error(),
+		)
+		return
+	}
+
+	// AliCloud may reformat the trust policy, which is not a drift as long as
+	// it grants the same permissions.
+	assumeRolePolicyDocument := tea.StringValue(role.AssumeRolePolicyDocument)
+	if !ramPolicyDocumentsEquivalent(state.AssumeRolePolicyDocument.ValueString(), assumeRolePolicyDocument) {
+		state.AssumeRolePolicyDocument = types.StringValue(assumeRolePolicyDocument)
+	}
+	state.MaxSessionDuration = types.Int64Value(tea.Int64Value(role.MaxSessionDuration))
+	if description := tea.StringValue(role.Description); description != "" || !state.Description.IsNull() {
+		state.Description = types.StringValue(description)
+	}
+	state.RoleId = types.StringValue(tea.StringValue(role.RoleId))
+	state.Arn = types.StringValue(tea.StringValue(role.Arn))
+
+	// Only the declared policies are checked, so that policies attached
+	// outside Terraform do not show up as drift.
+	if !state.ManagedPolicies.IsNull() {
+		attachedPolicies, err := r.listPoliciesForRole(state.RoleName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Read Policies for Role",
+				err.Error(),
+			)
+			return
+		}
+
+		managedPolicies, diags := getRamRoleManagedPolicies(ctx, state.ManagedPolicies)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		stillAttachedPolicies := make([]*ramRoleManagedPolicy, 0)
+		for _, managedPolicy := range managedPolicies {
+			if attachedPolicies[managedPolicy.key()] != nil {
+				stillAttachedPolicies = append(stillAttachedPolicies, managedPolicy)
+			}
+		}
+
+		managedPoliciesList, diags := types.ListValueFrom(ctx, ramRoleManagedPolicyType(), stillAttachedPolicies)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.ManagedPolicies = managedPoliciesList
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *ramRoleResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.AssumeRolePolicyDocument.Equal(state.AssumeRolePolicyDocument) ||
+		!plan.MaxSessionDuration.Equal(state.MaxSessionDuration) ||
+		!plan.Description.Equal(state.Description) {
+		updateRoleRequest := &alicloudRamClient.UpdateRoleRequest{
+			RoleName:                    tea.String(plan.RoleName.ValueString()),
+			NewAssumeRolePolicyDocument: tea.String(plan.AssumeRolePolicyDocument.ValueString()),
+			NewMaxSessionDuration:       tea.Int64(plan.MaxSessionDuration.ValueInt64()),
+			NewDescription:              tea.String(plan.Description.ValueString()),
+		}
+
+		updateRole := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			if _, err := r.client.UpdateRoleWithOptions(updateRoleRequest, runtime); err != nil {
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(updateRole, reconnectBackoff); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update RAM Role.",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	planManagedPolicies, diags := getRamRoleManagedPolicies(ctx, plan.ManagedPolicies)
+	resp.Diagnostics.Append(diags...)
+	stateManagedPolicies, diags := getRamRoleManagedPolicies(ctx, state.ManagedPolicies)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planPolicies := make(map[string]bool)
+	for _, managedPolicy := range planManagedPolicies {
+		planPolicies[managedPolicy.key()] = true
+	}
+	statePolicies := make(map[string]bool)
+	for _, managedPolicy := range stateManagedPolicies {
+		statePolicies[managedPolicy.key()] = true
+	}
+
+	policiesToDetach := make([]*ramRoleManagedPolicy, 0)
+	for _, managedPolicy := range stateManagedPolicies {
+		if !planPolicies[managedPolicy.key()] {
+			policiesToDetach = append(policiesToDetach, managedPolicy)
+		}
+	}
+	policiesToAttach := make([]*ramRoleManagedPolicy, 0)
+	for _, managedPolicy := range planManagedPolicies {
+		if !statePolicies[managedPolicy.key()] {
+			policiesToAttach = append(policiesToAttach, managedPolicy)
+		}
+	}
+
+	if err := r.detachPoliciesFromRole(plan.RoleName.ValueString(), policiesToDetach); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Detach Policy from Role.",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.attachPoliciesToRole(plan.RoleName.ValueString(), policiesToAttach); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Attach Policy to Role.",
+			err.Error(),
+		)
+		return
+	}
+
+	state.AssumeRolePolicyDocument = plan.AssumeRolePolicyDocument
+	state.MaxSessionDuration = plan.MaxSessionDuration
+	state.Description = plan.Description
+	state.ManagedPolicies = plan.ManagedPolicies
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *ramRoleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A role can only be deleted after all its policies are detached,
+	// including the policies attached outside Terraform.
+	attachedPolicies, err := r.listPoliciesForRole(state.RoleName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Policies for Role",
+			err.Error(),
+		)
+		return
+	}
+
+	if err := r.detachPoliciesFromRole(state.RoleName.ValueString(), attachedPolicies.list()); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Detach Policy from Role.",
+			err.Error(),
+		)
+		return
+	}
+
+	deleteRole := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteRoleRequest := &alicloudRamClient.DeleteRoleRequest{
+			RoleName: tea.String(state.RoleName.ValueString()),
+		}
+
+		if _, err := r.client.DeleteRoleWithOptions(deleteRoleRequest, runtime); err != nil {
+			if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "EntityNotExist.Role" {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteRole, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete RAM Role",
+			err.Error(),
+		)
+		return
+	}
+}
+
+// ImportState imports the role by its name. managed_policies is left null, so
+// only the policies declared in the configuration are attached on the next
+// apply, and the policies already attached to the role are not detached.
+func (r *ramRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("role_name"), req, resp)
+}
+
+func (r *ramRoleResource) getRole(roleName string) (role *alicloudRamClient.GetRoleResponseBodyRole, err error) {
+	getRole := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		getRoleRequest := &alicloudRamClient.GetRoleRequest{
+			RoleName: tea.String(roleName),
+		}
+
+		getRoleResponse, err := r.client.GetRoleWithOptions(getRoleRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		role = getRoleResponse.Body.Role
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	err = backoff.Retry(getRole, reconnectBackoff)
+	return
+}
+
+// ramRoleAttachedPolicies is the set of policies attached to a role, keyed by
+// the policy type and name.
+type ramRoleAttachedPolicies map[string]*ramRoleManagedPolicy
+
+// list returns the attached policies sorted by the policy type and name.
+func (p ramRoleAttachedPolicies) list() []*ramRoleManagedPolicy {
+	keys := make([]string, 0, len(p))
+	for key := range p {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	policies := make([]*ramRoleManagedPolicy, 0, len(p))
+	for _, key := range keys {
+		policies = append(policies, p[key])
+	}
+	return policies
+}
+
+func (r *ramRoleResource) listPoliciesForRole(roleName string) (ramRoleAttachedPolicies, error) {
+	attachedPolicies := make(ramRoleAttachedPolicies)
+
+	listPoliciesForRole := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		listPoliciesForRoleRequest := &alicloudRamClient.ListPoliciesForRoleRequest{
+			RoleName: tea.String(roleName),
+		}
+
+		listPoliciesForRoleResponse, err := r.client.ListPoliciesForRoleWithOptions(listPoliciesForRoleRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+
+		if listPoliciesForRoleResponse.Body.Policies != nil {
+			for _, policy := range listPoliciesForRoleResponse.Body.Policies.Policy {
+				managedPolicy := &ramRoleManagedPolicy{
+					PolicyName: types.StringValue(tea.StringValue(policy.PolicyName)),
+					PolicyType: types.StringValue(tea.StringValue(policy.PolicyType)),
+				}
+				attachedPolicies[managedPolicy.key()] = managedPolicy
+			}
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(listPoliciesForRole, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	return attachedPolicies, nil
+}
+
+func (r *ramRoleResource) attachPoliciesToRole(roleName string, policies []*ramRoleManagedPolicy) error {
+	for _, policy := range policies {
+		attachPolicyToRoleRequest := &alicloudRamClient.AttachPolicyToRoleRequest{
+			PolicyName: tea.String(policy.PolicyName.ValueString()),
+			PolicyType: tea.String(policy.PolicyType.ValueString()),
+			RoleName:   tea.String(roleName),
+		}
+
+		attachPolicyToRole := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			if _, err := r.client.AttachPolicyToRoleWithOptions(attachPolicyToRoleRequest, runtime); err != nil {
+				// The policy may have been attached outside Terraform.
+				if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "EntityAlreadyExists.Role.Policy" {
+					return nil
+				}
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(attachPolicyToRole, reconnectBackoff); err != nil {
+			return fmt.Errorf("failed to attach policy %s: %w", policy.PolicyName.ValueString(), err)
+		}
+	}
+
+	return nil
+}
+
+func (r *ramRoleResource) detachPoliciesFromRole(roleName string, policies []*ramRoleManagedPolicy) error {
+	for _, policy := range policies {
+		detachPolicyFromRoleRequest := &alicloudRamClient.DetachPolicyFromRoleRequest{
+			PolicyName: tea.String(policy.PolicyName.ValueString()),
+			PolicyType: tea.String(policy.PolicyType.ValueString()),
+			RoleName:   tea.String(roleName),
+		}
+
+		detachPolicyFromRole := func() error {
+			runtime := &util.RuntimeOptions{}
+
+			if _, err := r.client.DetachPolicyFromRoleWithOptions(detachPolicyFromRoleRequest, runtime); err != nil {
+				// The policy may have been detached outside Terraform.
+				if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "EntityNotExist.Role.Policy" {
+					return nil
+				}
+				return handleAPIError(err)
+			}
+			return nil
+		}
+
+		reconnectBackoff := backoff.NewExponentialBackOff()
+		reconnectBackoff.MaxElapsedTime = 30 * time.Second
+		if err := backoff.Retry(detachPolicyFromRole, reconnectBackoff); err != nil {
+			return fmt.Errorf("failed to detach policy %s: %w", policy.PolicyName.ValueString(), err)
+		}
+	}
+
+	return nil
+}
+
+func (p *ramRoleManagedPolicy) key() string {
+	return p.PolicyType.ValueString() + " " + p.PolicyName.ValueString()
+}
+
+func ramRoleManagedPolicyType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"policy_name": types.StringType,
+			"policy_type": types.StringType,
+		},
+	}
+}
+
+func getRamRoleManagedPolicies(ctx context.Context, managedPolicies types.List) ([]*ramRoleManagedPolicy, diag.Diagnostics) {
+	policies := make([]*ramRoleManagedPolicy, 0)
+	if managedPolicies.IsNull() || managedPolicies.IsUnknown() {
+		return policies, nil
+	}
+
+	diags := managedPolicies.ElementsAs(ctx, &policies, false)
+	return policies, diags
+}
@@ -0,0 +1,334 @@
+package alicloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource              = &ramSecurityPreferenceResource{}
+	_ resource.ResourceWithConfigure = &ramSecurityPreferenceResource{}
+)
+
+func NewRamSecurityPreferenceResource() resource.Resource {
+	return &ramSecurityPreferenceResource{}
+}
+
+type ramSecurityPreferenceResource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramSecurityPreferenceResourceModel struct {
+	AllowUserToChangePassword   types.Bool   `tfsdk:"allow_user_to_change_password"`
+	AllowUserToManageAccessKeys types.Bool   `tfsdk:"allow_user_to_manage_access_keys"`
+	AllowUserToManageMfaDevices types.Bool   `tfsdk:"allow_user_to_manage_mfa_devices"`
+	AllowUserToManagePublicKeys types.Bool   `tfsdk:"allow_user_to_manage_public_keys"`
+	EnableSaveMfaTicket         types.Bool   `tfsdk:"enable_save_mfa_ticket"`
+	LoginNetworkMasks           types.String `tfsdk:"login_network_masks"`
+	LoginSessionDuration        types.Int64  `tfsdk:"login_session_duration"`
+	MinimumPasswordLength       types.Int64  `tfsdk:"minimum_password_length"`
+	RequireLowercaseCharacters  types.Bool   `tfsdk:"require_lowercase_characters"`
+	RequireUppercaseCharacters  types.Bool   `tfsdk:"require_uppercase_characters"`
+	RequireNumbers              types.Bool   `tfsdk:"require_numbers"`
+	RequireSymbols              types.Bool   `tfsdk:"require_symbols"`
+	HardExpiry                  types.Bool   `tfsdk:"hard_expiry"`
+	MaxPasswordAge              types.Int64  `tfsdk:"max_password_age"`
+	PasswordReusePrevention     types.Int64  `tfsdk:"password_reuse_prevention"`
+	MaxLoginAttempts            types.Int64  `tfsdk:"max_login_attempts"`
+}
+
+// defaultRamSecurityPreference is the security preference and password
+// policy of a new account, which is restored when the resource is deleted.
+var defaultRamSecurityPreference = &ramSecurityPreferenceResourceModel{
+	AllowUserToChangePassword:   types.BoolValue(true),
+	AllowUserToManageAccessKeys: types.BoolValue(false),
+	AllowUserToManageMfaDevices: types.BoolValue(true),
+	AllowUserToManagePublicKeys: types.BoolValue(false),
+	EnableSaveMfaTicket:         types.BoolValue(false),
+	LoginNetworkMasks:           types.StringValue(""),
+	LoginSessionDuration:        types.Int64Value(6),
+	MinimumPasswordLength:       types.Int64Value(8),
+	RequireLowercaseCharacters:  types.BoolValue(false),
+	RequireUppercaseCharacters:  types.BoolValue(false),
+	RequireNumbers:              types.BoolValue(false),
+	RequireSymbols:              types.BoolValue(false),
+	HardExpiry:                  types.BoolValue(false),
+	MaxPasswordAge:              types.Int64Value(0),
+	PasswordReusePrevention:     types.Int64Value(0),
+	MaxLoginAttempts:            types.Int64Value(0),
+}
+
+func (r *ramSecurityPreferenceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_security_preference"
+}
+
+func (r *ramSecurityPreferenceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	boolAttribute := func(description string, defaultValue types.Bool) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(defaultValue.ValueBool()),
+		}
+	}
+	int64Attribute := func(description string, defaultValue types.Int64, min, max int64) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			Validators: []validator.Int64{
+				int64validator.Between(min, max),
+			},
+			Default: int64default.StaticInt64(defaultValue.ValueInt64()),
+		}
+	}
+
+	d := defaultRamSecurityPreference
+	resp.Schema = schema.Schema{
+		Description: "Provides a RAM Security Preference resource, which manages the account-level security " +
+			"preference and password policy of RAM users. Only one of this resource should be declared per " +
+			"account, deleting it restores the default settings.",
+		Attributes: map[string]schema.Attribute{
+			"allow_user_to_change_password": boolAttribute(
+				"Whether RAM users can change their passwords. Default to true.",
+				d.AllowUserToChangePassword),
+			"allow_user_to_manage_access_keys": boolAttribute(
+				"Whether RAM users can manage their access keys. Default to false.",
+				d.AllowUserToManageAccessKeys),
+			"allow_user_to_manage_mfa_devices": boolAttribute(
+				"Whether RAM users can bind and unbind their MFA devices. Default to true.",
+				d.AllowUserToManageMfaDevices),
+			"allow_user_to_manage_public_keys": boolAttribute(
+				"Whether RAM users can manage their public keys. Default to false.",
+				d.AllowUserToManagePublicKeys),
+			"enable_save_mfa_ticket": boolAttribute(
+				"Whether RAM users can save their MFA verification for 7 days, which skips "+
+					"MFA at logon. Set to false to enforce MFA at every logon. Default to false.",
+				d.EnableSaveMfaTicket),
+			"login_network_masks": schema.StringAttribute{
+				Description: "The subnet masks, separated by semicolons, from which RAM users can logon " +
+					"to the console. Empty means any network. Default to empty.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(d.LoginNetworkMasks.ValueString()),
+			},
+			"login_session_duration": int64Attribute(
+				"The validity period of a logon session in hours. Valid values: 1 to 24. Default to 6.",
+				d.LoginSessionDuration, 1, 24),
+			"minimum_password_length": int64Attribute(
+				"The minimum length of passwords. Valid values: 8 to 32. Default to 8.",
+				d.MinimumPasswordLength, 8, 32),
+			"require_lowercase_characters": boolAttribute(
+				"Whether passwords must contain lowercase characters. Default to false.",
+				d.RequireLowercaseCharacters),
+			"require_uppercase_characters": boolAttribute(
+				"Whether passwords must contain uppercase characters. Default to false.",
+				d.RequireUppercaseCharacters),
+			"require_numbers": boolAttribute(
+				"Whether passwords must contain numbers. Default to false.",
+				d.RequireNumbers),
+			"require_symbols": boolAttribute(
+				"Whether passwords must contain symbols. Default to false.",
+				d.RequireSymbols),
+			"hard_expiry": boolAttribute(
+				"Whether RAM users are prevented from logon after their passwords expire. Default to false.",
+				d.HardExpiry),
+			"max_password_age": int64Attribute(
+				"The number of days for which a password is valid. 0 means never expire. "+
+					"Valid values: 0 to 1095. Default to 0.",
+				d.MaxPasswordAge, 0, 1095),
+			"password_reuse_prevention": int64Attribute(
+				"The number of previous passwords that cannot be reused. 0 means no limit. "+
+					"Valid values: 0 to 24. Default to 0.",
+				d.PasswordReusePrevention, 0, 24),
+			"max_login_attempts": int64Attribute(
+				"The number of failed logon attempts within an hour before logon is locked. "+
+					"0 means no limit. Valid values: 0 to 32. Default to 0.",
+				d.MaxLoginAttempts, 0, 32),
+		},
+	}
+}
+
+func (r *ramSecurityPreferenceResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (r *ramSecurityPreferenceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ramSecurityPreferenceResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setSecurityPreference(plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Set Security Preference.",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramSecurityPreferenceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ramSecurityPreferenceResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readSecurityPreference := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		getSecurityPreferenceResponse, err := r.client.GetSecurityPreferenceWithOptions(runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		securityPreference := getSecurityPreferenceResponse.Body.SecurityPreference
+		if p := securityPreference.AccessKeyPreference; p != nil {
+			state.AllowUserToManageAccessKeys = types.BoolValue(tea.BoolValue(p.AllowUserToManageAccessKeys))
+		}
+		if p := securityPreference.LoginProfilePreference; p != nil {
+			state.AllowUserToChangePassword = types.BoolValue(tea.BoolValue(p.AllowUserToChangePassword))
+			state.EnableSaveMfaTicket = types.BoolValue(tea.BoolValue(p.EnableSaveMFATicket))
+			state.LoginNetworkMasks = types.StringValue(tea.StringValue(p.LoginNetworkMasks))
+			state.LoginSessionDuration = types.Int64Value(int64(tea.Int32Value(p.LoginSessionDuration)))
+		}
+		if p := securityPreference.MFAPreference; p != nil {
+			state.AllowUserToManageMfaDevices = types.BoolValue(tea.BoolValue(p.AllowUserToManageMFADevices))
+		}
+		if p := securityPreference.PublicKeyPreference; p != nil {
+			state.AllowUserToManagePublicKeys = types.BoolValue(tea.BoolValue(p.AllowUserToManagePublicKeys))
+		}
+
+		getPasswordPolicyResponse, err := r.client.GetPasswordPolicyWithOptions(runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		passwordPolicy := getPasswordPolicyResponse.Body.PasswordPolicy
+		state.MinimumPasswordLength = types.Int64Value(int64(tea.Int32Value(passwordPolicy.MinimumPasswordLength)))
+		state.RequireLowercaseCharacters = types.BoolValue(tea.BoolValue(passwordPolicy.RequireLowercaseCharacters))
+		state.RequireUppercaseCharacters = types.BoolValue(tea.BoolValue(passwordPolicy.RequireUppercaseCharacters))
+		state.RequireNumbers = types.BoolValue(tea.BoolValue(passwordPolicy.RequireNumbers))
+		state.RequireSymbols = types.BoolValue(tea.BoolValue(passwordPolicy.RequireSymbols))
+		state.HardExpiry = types.BoolValue(tea.BoolValue(passwordPolicy.HardExpiry))
+		state.MaxPasswordAge = types.Int64Value(int64(tea.Int32Value(passwordPolicy.MaxPasswordAge)))
+		state.PasswordReusePrevention = types.Int64Value(int64(tea.Int32Value(passwordPolicy.PasswordReusePrevention)))
+		state.MaxLoginAttempts = types.Int64Value(int64(tea.Int32Value(passwordPolicy.MaxLoginAttemps)))
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(readSecurityPreference, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Security Preference",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramSecurityPreferenceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *ramSecurityPreferenceResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setSecurityPreference(plan); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Set Security Preference.",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramSecurityPreferenceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if err := r.setSecurityPreference(defaultRamSecurityPreference); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Reset Security Preference",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ramSecurityPreferenceResource) setSecurityPreference(model *ramSecurityPreferenceResourceModel) error {
+	setSecurityPreference := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		setSecurityPreferenceRequest := &alicloudRamClient.SetSecurityPreferenceRequest{
+			AllowUserToChangePassword:   tea.Bool(model.AllowUserToChangePassword.ValueBool()),
+			AllowUserToManageAccessKeys: tea.Bool(model.AllowUserToManageAccessKeys.ValueBool()),
+			AllowUserToManageMFADevices: tea.Bool(model.AllowUserToManageMfaDevices.ValueBool()),
+			AllowUserToManagePublicKeys: tea.Bool(model.AllowUserToManagePublicKeys.ValueBool()),
+			EnableSaveMFATicket:         tea.Bool(model.EnableSaveMfaTicket.ValueBool()),
+			LoginNetworkMasks:           tea.String(model.LoginNetworkMasks.ValueString()),
+			LoginSessionDuration:        tea.Int32(int32(model.LoginSessionDuration.ValueInt64())),
+		}
+
+		if _, err := r.client.SetSecurityPreferenceWithOptions(setSecurityPreferenceRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+
+		setPasswordPolicyRequest := &alicloudRamClient.SetPasswordPolicyRequest{
+			MinimumPasswordLength:      tea.Int32(int32(model.MinimumPasswordLength.ValueInt64())),
+			RequireLowercaseCharacters: tea.Bool(model.RequireLowercaseCharacters.ValueBool()),
+			RequireUppercaseCharacters: tea.Bool(model.RequireUppercaseCharacters.ValueBool()),
+			RequireNumbers:             tea.Bool(model.RequireNumbers.ValueBool()),
+			RequireSymbols:             tea.Bool(model.RequireSymbols.ValueBool()),
+			HardExpiry:                 tea.Bool(model.HardExpiry.ValueBool()),
+			MaxPasswordAge:             tea.Int32(int32(model.MaxPasswordAge.ValueInt64())),
+			PasswordReusePrevention:    tea.Int32(int32(model.PasswordReusePrevention.ValueInt64())),
+			MaxLoginAttemps:            tea.Int32(int32(model.MaxLoginAttempts.ValueInt64())),
+		}
+
+		if _, err := r.client.SetPasswordPolicyWithOptions(setPasswordPolicyRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(setSecurityPreference, reconnectBackoff)
+}
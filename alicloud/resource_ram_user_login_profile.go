@@ -0,0 +1,403 @@
+package alicloud
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	alicloudRamClient "github.com/alibabacloud-go/ram-20150501/v2/client"
+	util "github.com/alibabacloud-go/tea-utils/v2/service"
+	"github.com/alibabacloud-go/tea/tea"
+)
+
+var (
+	_ resource.Resource                = &ramUserLoginProfileResource{}
+	_ resource.ResourceWithConfigure   = &ramUserLoginProfileResource{}
+	_ resource.ResourceWithImportState = &ramUserLoginProfileResource{}
+)
+
+func NewRamUserLoginProfileResource() resource.Resource {
+	return &ramUserLoginProfileResource{}
+}
+
+type ramUserLoginProfileResource struct {
+	client *alicloudRamClient.Client
+}
+
+type ramUserLoginProfileResourceModel struct {
+	UserName              types.String `tfsdk:"user_name"`
+	PasswordResetRequired types.Bool   `tfsdk:"password_reset_required"`
+	MfaBindRequired       types.Bool   `tfsdk:"mfa_bind_required"`
+	Password              types.String `tfsdk:"password"`
+	PgpKey                types.String `tfsdk:"pgp_key"`
+	PasswordLength        types.Int64  `tfsdk:"password_length"`
+	EncryptedPassword     types.String `tfsdk:"encrypted_password"`
+	KeyFingerprint        types.String `tfsdk:"key_fingerprint"`
+}
+
+func (r *ramUserLoginProfileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ram_user_login_profile"
+}
+
+func (r *ramUserLoginProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a RAM User Login Profile resource, which enables console login for a RAM user.",
+		Attributes: map[string]schema.Attribute{
+			"user_name": schema.StringAttribute{
+				Description: "The username of the RAM user.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password_reset_required": schema.BoolAttribute{
+				Description: "Whether the user must change the password at the next logon. Default to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"mfa_bind_required": schema.BoolAttribute{
+				Description: "Whether the user must bind an MFA device at the next logon. Default to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"password": schema.StringAttribute{
+				Description: "The initial password of the user. Exactly one of password or pgp_key must be set.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("pgp_key")),
+				},
+			},
+			"pgp_key": schema.StringAttribute{
+				Description: "The PGP public key, either ASCII armored or base64-encoded. If set, an initial " +
+					"password is generated and only exposed in encrypted_password. Changing it recreates " +
+					"the login profile with a new password.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password_length": schema.Int64Attribute{
+				Description: "The length of the generated password. Valid values: 8 to 32. Default to 20.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(8, 32),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Default: int64default.StaticInt64(20),
+			},
+			"encrypted_password": schema.StringAttribute{
+				Description: "The base64-encoded generated password encrypted with pgp_key.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_fingerprint": schema.StringAttribute{
+				Description: "The fingerprint of the PGP key used to encrypt the password.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ramUserLoginProfileResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(alicloudClients).ramClient
+}
+
+func (r *ramUserLoginProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *ramUserLoginProfileResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := &ramUserLoginProfileResourceModel{}
+	state.UserName = plan.UserName
+	state.PasswordResetRequired = plan.PasswordResetRequired
+	state.MfaBindRequired = plan.MfaBindRequired
+	state.Password = plan.Password
+	state.PgpKey = plan.PgpKey
+	state.PasswordLength = plan.PasswordLength
+	state.EncryptedPassword = types.StringNull()
+	state.KeyFingerprint = types.StringNull()
+
+	password := plan.Password.ValueString()
+	if !plan.PgpKey.IsNull() {
+		var err error
+		if password, err = generateRamPassword(int(plan.PasswordLength.ValueInt64())); err != nil {
+			resp.Diagnostics.AddError(
+				"[ERROR] Failed to Generate Password.",
+				err.Error(),
+			)
+			return
+		}
+
+		encryptedPassword, keyFingerprint, err := encryptWithPgpKey(plan.PgpKey.ValueString(), password)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pgp_key"),
+				"[ERROR] Failed to Encrypt Password.",
+				err.Error(),
+			)
+			return
+		}
+		state.EncryptedPassword = types.StringValue(encryptedPassword)
+		state.KeyFingerprint = types.StringValue(keyFingerprint)
+	}
+
+	createLoginProfile := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		createLoginProfileRequest := &alicloudRamClient.CreateLoginProfileRequest{
+			UserName:              tea.String(plan.UserName.ValueString()),
+			Password:              tea.String(password),
+			PasswordResetRequired: tea.Bool(plan.PasswordResetRequired.ValueBool()),
+			MFABindRequired:       tea.Bool(plan.MfaBindRequired.ValueBool()),
+		}
+
+		if _, err := r.client.CreateLoginProfileWithOptions(createLoginProfileRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(createLoginProfile, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Create Login Profile.",
+			err.Error(),
+		)
+		return
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramUserLoginProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *ramUserLoginProfileResourceModel
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var loginProfile *alicloudRamClient.GetLoginProfileResponseBodyLoginProfile
+	getLoginProfile := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		getLoginProfileRequest := &alicloudRamClient.GetLoginProfileRequest{
+			UserName: tea.String(state.UserName.ValueString()),
+		}
+
+		getLoginProfileResponse, err := r.client.GetLoginProfileWithOptions(getLoginProfileRequest, runtime)
+		if err != nil {
+			return handleAPIError(err)
+		}
+		loginProfile = getLoginProfileResponse.Body.LoginProfile
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(getLoginProfile, reconnectBackoff); err != nil {
+		if _t, ok := err.(*tea.SDKError); ok && (tea.StringValue(_t.Code) == "EntityNotExist.User.LoginProfile" ||
+			tea.StringValue(_t.Code) == "EntityNotExist.User") {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Read Login Profile",
+			err.Error(),
+		)
+		return
+	}
+
+	state.PasswordResetRequired = types.BoolValue(tea.BoolValue(loginProfile.PasswordResetRequired))
+	state.MfaBindRequired = types.BoolValue(tea.BoolValue(loginProfile.MFABindRequired))
+	// The password length is only known for generated passwords.
+	if state.PasswordLength.IsNull() {
+		state.PasswordLength = types.Int64Value(20)
+	}
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramUserLoginProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *ramUserLoginProfileResourceModel
+	getPlanDiags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(getPlanDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	getStateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(getStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateLoginProfileRequest := &alicloudRamClient.UpdateLoginProfileRequest{
+		UserName:              tea.String(plan.UserName.ValueString()),
+		PasswordResetRequired: tea.Bool(plan.PasswordResetRequired.ValueBool()),
+		MFABindRequired:       tea.Bool(plan.MfaBindRequired.ValueBool()),
+	}
+	if !plan.Password.IsNull() && !plan.Password.Equal(state.Password) {
+		updateLoginProfileRequest.Password = tea.String(plan.Password.ValueString())
+	}
+
+	updateLoginProfile := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		if _, err := r.client.UpdateLoginProfileWithOptions(updateLoginProfileRequest, runtime); err != nil {
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(updateLoginProfile, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Update Login Profile.",
+			err.Error(),
+		)
+		return
+	}
+
+	state.PasswordResetRequired = plan.PasswordResetRequired
+	state.MfaBindRequired = plan.MfaBindRequired
+	state.Password = plan.Password
+
+	setStateDiags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(setStateDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ramUserLoginProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *ramUserLoginProfileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteLoginProfile := func() error {
+		runtime := &util.RuntimeOptions{}
+
+		deleteLoginProfileRequest := &alicloudRamClient.DeleteLoginProfileRequest{
+			UserName: tea.String(state.UserName.ValueString()),
+		}
+
+		if _, err := r.client.DeleteLoginProfileWithOptions(deleteLoginProfileRequest, runtime); err != nil {
+			if _t, ok := err.(*tea.SDKError); ok && tea.StringValue(_t.Code) == "EntityNotExist.User.LoginProfile" {
+				return nil
+			}
+			return handleAPIError(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err := backoff.Retry(deleteLoginProfile, reconnectBackoff); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete Login Profile",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ramUserLoginProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("user_name"), req, resp)
+}
+
+// generateRamPassword generates a random password which contains lowercase
+// and uppercase characters, numbers and symbols, so that it satisfies any
+// password policy of RAM.
+func generateRamPassword(length int) (string, error) {
+	characterSets := []string{
+		"abcdefghijklmnopqrstuvwxyz",
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+		"0123456789",
+		"!@#$%^&*()_+-=[]{}|",
+	}
+	allCharacters := ""
+	for _, characterSet := range characterSets {
+		allCharacters += characterSet
+	}
+
+	randomCharacter := func(characters string) (byte, error) {
+		i, err := rand.Int(rand.Reader, big.NewInt(int64(len(characters))))
+		if err != nil {
+			return 0, err
+		}
+		return characters[i.Int64()], nil
+	}
+
+	password := make([]byte, 0, length)
+	for _, characterSet := range characterSets {
+		character, err := randomCharacter(characterSet)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, character)
+	}
+	for len(password) < length {
+		character, err := randomCharacter(allCharacters)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, character)
+	}
+
+	// Shuffle so that the required characters are not always at the front.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
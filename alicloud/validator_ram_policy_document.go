@@ -0,0 +1,180 @@
+package alicloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	_ validator.String = ramPolicyDocumentValidator{}
+)
+
+var (
+	ramPolicyActionRegex = regexp.MustCompile(`^[a-zA-Z0-9\-*?]+:[a-zA-Z0-9\-_*?]+$`)
+	ramPolicyArnRegex    = regexp.MustCompile(`^acs:[a-zA-Z0-9\-*?]+:[a-zA-Z0-9\-*?]*:[0-9*?]*:.+$`)
+)
+
+// getRamPolicyConditionOperators returns the condition operators supported by
+// RAM. The operators may be prefixed with ForAnyValue: or ForAllValues: when
+// the condition key has multiple values.
+func getRamPolicyConditionOperators() []string {
+	return []string{
+		"StringEquals",
+		"StringNotEquals",
+		"StringEqualsIgnoreCase",
+		"StringNotEqualsIgnoreCase",
+		"StringLike",
+		"StringNotLike",
+		"NumericEquals",
+		"NumericNotEquals",
+		"NumericLessThan",
+		"NumericLessThanEquals",
+		"NumericGreaterThan",
+		"NumericGreaterThanEquals",
+		"DateEquals",
+		"DateNotEquals",
+		"DateLessThan",
+		"DateLessThanEquals",
+		"DateGreaterThan",
+		"DateGreaterThanEquals",
+		"Bool",
+		"IpAddress",
+		"NotIpAddress",
+	}
+}
+
+// ramPolicyDocumentValidator validates that a string is a RAM policy document
+// which AliCloud will accept, so that invalid policies are reported by
+// terraform validate instead of failing in the middle of apply.
+type ramPolicyDocumentValidator struct{}
+
+// validRamPolicyDocument returns a validator which lints a RAM policy document.
+func validRamPolicyDocument() validator.String {
+	return ramPolicyDocumentValidator{}
+}
+
+func (v ramPolicyDocumentValidator) Description(_ context.Context) string {
+	return "value must be a valid RAM policy document"
+}
+
+func (v ramPolicyDocumentValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ramPolicyDocumentValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	lintErrors, lintWarnings := lintRamPolicyDocument(req.ConfigValue.ValueString())
+	for _, lintError := range lintErrors {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid RAM Policy Document",
+			lintError,
+		)
+	}
+	for _, lintWarning := range lintWarnings {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Overly Permissive RAM Policy Document",
+			lintWarning,
+		)
+	}
+}
+
+// lintRamPolicyDocument checks a RAM policy document offline and returns the
+// problems that will be rejected by AliCloud as errors, and the grants that
+// are overly permissive as warnings.
+func lintRamPolicyDocument(document string) (lintErrors []string, lintWarnings []string) {
+	lintErrors = make([]string, 0)
+	lintWarnings = make([]string, 0)
+
+	// Unknown fields are rejected, so that typos such as "Actions" are caught.
+	policy := &ramPolicy{}
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(policy); err != nil {
+		lintErrors = append(lintErrors, fmt.Sprintf("The policy document is not a valid JSON policy: %s.", err.Error()))
+		return
+	}
+
+	compactDocument := new(bytes.Buffer)
+	if err := json.Compact(compactDocument, []byte(document)); err == nil && compactDocument.Len() > maxLength {
+		lintErrors = append(lintErrors, fmt.Sprintf("The policy document has %d characters, which exceeds the maximum length of %d characters.",
+			compactDocument.Len(), maxLength))
+	}
+
+	if policy.Version != "1" {
+		lintErrors = append(lintErrors, fmt.Sprintf("The Version of the policy document must be \"1\", got \"%s\".", policy.Version))
+	}
+
+	if len(policy.Statement) == 0 {
+		lintErrors = append(lintErrors, "The policy document must contain at least one Statement.")
+	}
+
+	operators := make(map[string]bool)
+	for _, operator := range getRamPolicyConditionOperators() {
+		operators[operator] = true
+	}
+
+	for i, statement := range policy.Statement {
+		if statement == nil {
+			lintErrors = append(lintErrors, fmt.Sprintf("Statement %d must not be empty.", i))
+			continue
+		}
+
+		if statement.Effect != "Allow" && statement.Effect != "Deny" {
+			lintErrors = append(lintErrors, fmt.Sprintf("The Effect of statement %d must be Allow or Deny, got \"%s\".", i, statement.Effect))
+		}
+
+		if len(statement.Action) == 0 && len(statement.NotAction) == 0 {
+			lintErrors = append(lintErrors, fmt.Sprintf("Statement %d must contain Action or NotAction.", i))
+		}
+		if len(statement.Action) > 0 && len(statement.NotAction) > 0 {
+			lintErrors = append(lintErrors, fmt.Sprintf("Statement %d must not contain both Action and NotAction.", i))
+		}
+		for _, action := range append(append(ramPolicyStringList{}, statement.Action...), statement.NotAction...) {
+			if action != "*" && !ramPolicyActionRegex.MatchString(action) {
+				lintErrors = append(lintErrors, fmt.Sprintf("The action \"%s\" of statement %d must be in the format of service:Action.", action, i))
+			}
+		}
+
+		// Trust policies of RAM roles specify Principal instead of Resource.
+		if len(statement.Principal) == 0 && len(statement.Resource) == 0 && len(statement.NotResource) == 0 {
+			lintErrors = append(lintErrors, fmt.Sprintf("Statement %d must contain Resource or NotResource.", i))
+		}
+		if len(statement.Resource) > 0 && len(statement.NotResource) > 0 {
+			lintErrors = append(lintErrors, fmt.Sprintf("Statement %d must not contain both Resource and NotResource.", i))
+		}
+		for _, resource := range append(append(ramPolicyStringList{}, statement.Resource...), statement.NotResource...) {
+			if resource != "*" && !ramPolicyArnRegex.MatchString(resource) {
+				lintErrors = append(lintErrors, fmt.Sprintf("The resource \"%s\" of statement %d must be * or in the format of acs:service:region:account:path.", resource, i))
+			}
+		}
+
+		for operator := range statement.Condition {
+			baseOperator := strings.TrimPrefix(strings.TrimPrefix(operator, "ForAnyValue:"), "ForAllValues:")
+			if !operators[baseOperator] {
+				lintErrors = append(lintErrors, fmt.Sprintf("The condition operator \"%s\" of statement %d is not supported by RAM.", operator, i))
+			}
+		}
+
+		if statement.Effect == "Allow" {
+			for _, action := range statement.Action {
+				if action == "*" || action == "*:*" || strings.EqualFold(action, "ram:*") {
+					lintWarnings = append(lintWarnings, fmt.Sprintf("Statement %d grants \"%s\", which allows privilege escalation. "+
+						"Consider granting only the required actions.", i, action))
+				}
+			}
+		}
+	}
+
+	return
+}
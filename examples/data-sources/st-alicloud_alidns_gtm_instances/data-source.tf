@@ -0,0 +1,8 @@
+data "st-alicloud_alidns_gtm_instances" "example" {
+  name_regex        = "^prod-"
+  resource_group_id = "rg-abc123"
+}
+
+output "gtm_instances" {
+  value = data.st-alicloud_alidns_gtm_instances.example.instances
+}
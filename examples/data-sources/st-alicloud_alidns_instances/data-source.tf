@@ -0,0 +1,15 @@
+data "st-alicloud_alidns_instances" "example" {
+  version_code = "version_enterprise_basic"
+}
+
+locals {
+  available_alidns_instance_ids = [
+    for instance in data.st-alicloud_alidns_instances.example.instances :
+    instance.instance_id if instance.available_domain_numbers > 0
+  ]
+}
+
+resource "st-alicloud_alidns_domain_attachment" "example" {
+  instance_id = local.available_alidns_instance_ids[0]
+  domain      = "example.com"
+}
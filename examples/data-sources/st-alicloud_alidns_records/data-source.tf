@@ -0,0 +1,13 @@
+data "st-alicloud_alidns_records" "weighted" {
+  domain_name   = "example.com"
+  rr_regex      = "^www"
+  type          = "A"
+  weighted_only = true
+}
+
+output "weighted_records" {
+  value = {
+    for record in data.st-alicloud_alidns_records.weighted.records :
+    "${record.rr}/${record.value}" => record.weight
+  }
+}
@@ -0,0 +1,12 @@
+data "st-alicloud_ram_groups" "groups" {
+  name_regex              = "^ops-"
+  user_name               = "test-user"
+  expand_policy_documents = true
+}
+
+output "group_policies" {
+  value = {
+    for group in data.st-alicloud_ram_groups.groups.groups :
+    group.group_name => [for policy in group.attached_policies : policy.policy_name]
+  }
+}
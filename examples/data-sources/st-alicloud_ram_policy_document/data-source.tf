@@ -0,0 +1,34 @@
+data "st-alicloud_ram_policy_document" "ecs_read_only" {
+  statement {
+    sid       = "DescribeInstances"
+    effect    = "Allow"
+    actions   = ["ecs:Describe*"]
+    resources = ["acs:ecs:*:*:instance/*"]
+  }
+
+  statement {
+    effect    = "Deny"
+    actions   = ["ecs:DeleteInstance"]
+    resources = ["*"]
+
+    condition {
+      operator = "NotIpAddress"
+      variable = "acs:SourceIp"
+      values   = ["10.0.0.0/8"]
+    }
+  }
+}
+
+data "st-alicloud_ram_policy_document" "merged" {
+  source_policy_documents = [data.st-alicloud_ram_policy_document.ecs_read_only.json]
+
+  statement {
+    sid       = "DescribeInstances"
+    actions   = ["ecs:DescribeInstances", "ecs:DescribeDisks"]
+    resources = ["*"]
+  }
+}
+
+output "policy_document" {
+  value = data.st-alicloud_ram_policy_document.merged.minified_json
+}
@@ -0,0 +1,22 @@
+data "st-alicloud_ram_policy_simulation" "ecs_read_only" {
+  policy_documents = [for policy in st-alicloud_ram_policy.ram_policy.policies : policy.policy_document]
+
+  request {
+    action   = "ecs:DescribeInstances"
+    resource = "acs:ecs:cn-hongkong:123456789:instance/i-abcdefg"
+  }
+
+  request {
+    action   = "ecs:DeleteInstance"
+    resource = "acs:ecs:cn-hongkong:123456789:instance/i-abcdefg"
+
+    context {
+      key    = "acs:SourceIp"
+      values = ["10.0.0.1"]
+    }
+  }
+}
+
+output "all_allowed" {
+  value = data.st-alicloud_ram_policy_simulation.ecs_read_only.all_allowed
+}
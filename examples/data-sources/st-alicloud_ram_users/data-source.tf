@@ -0,0 +1,11 @@
+data "st-alicloud_ram_users" "admins" {
+  name_regex              = "^ops-"
+  group_name              = "test-group"
+  policy_name             = "AdministratorAccess"
+  policy_type             = "System"
+  expand_policy_documents = true
+}
+
+output "admin_users" {
+  value = [for user in data.st-alicloud_ram_users.admins.users : user.user_name]
+}
@@ -0,0 +1,18 @@
+resource "st-alicloud_alidns_domain_group" "brand" {
+  group_name = "brand"
+}
+
+resource "st-alicloud_alidns_domain" "brand" {
+  domain_name = "example.com"
+  group_id    = st-alicloud_alidns_domain_group.brand.group_id
+  remark      = "Brand domain"
+}
+
+resource "st-alicloud_alidns_domain_attachment" "brand" {
+  instance_id = "123456789012"
+  domain      = st-alicloud_alidns_domain.brand.domain_name
+}
+
+output "dns_servers" {
+  value = st-alicloud_alidns_domain.brand.dns_servers
+}
@@ -0,0 +1,3 @@
+resource "st-alicloud_alidns_domain_group" "brand" {
+  group_name = "brand"
+}
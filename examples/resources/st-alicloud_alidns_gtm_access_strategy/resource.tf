@@ -0,0 +1,30 @@
+resource "st-alicloud_alidns_gtm_access_strategy" "example" {
+  instance_id   = "gtm-cn-abc123"
+  strategy_name = "default"
+  lines         = ["default"]
+
+  default_address_pool = {
+    type                        = "IPV4"
+    lba_strategy                = "RATIO"
+    min_available_address_count = 1
+    address_pools = [
+      {
+        address_pool_id = "hra0hs"
+        weight          = 100
+      },
+    ]
+  }
+
+  failover_address_pool = {
+    type                        = "IPV4"
+    lba_strategy                = "ALL_RR"
+    min_available_address_count = 1
+    address_pools = [
+      {
+        address_pool_id = "hra0ht"
+      },
+    ]
+  }
+
+  access_mode = "AUTO"
+}
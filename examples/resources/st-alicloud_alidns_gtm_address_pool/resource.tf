@@ -0,0 +1,43 @@
+resource "st-alicloud_alidns_gtm_address_pool" "example" {
+  instance_id  = "gtm-cn-abc123"
+  name         = "web"
+  type         = "IPV4"
+  lba_strategy = "RATIO"
+
+  addresses = [
+    {
+      address = "192.0.2.10"
+      lines   = ["default"]
+      weight  = 80
+      remark  = "primary"
+    },
+    {
+      address = "192.0.2.20"
+      lines   = ["default"]
+      weight  = 20
+      mode    = "SMART"
+    },
+  ]
+
+  monitor = {
+    protocol         = "HTTP"
+    interval         = 60
+    evaluation_count = 2
+    timeout          = 5000
+    extend_info = jsonencode({
+      host = "www.example.com"
+      path = "/health"
+      code = 400
+    })
+    isp_city_nodes = [
+      {
+        isp_code  = "465"
+        city_code = "503"
+      },
+      {
+        isp_code  = "465"
+        city_code = "738"
+      },
+    ]
+  }
+}
@@ -7,4 +7,8 @@ resource "st-alicloud_alidns_gtm_instance" "test2" {
   package_edition   = "standard"
   instance_type     = "intl"
   strategy_mode     = "GEO"
+  period            = 12
+  renewal_status    = "AutoRenewal"
+  renew_period      = 12
+  on_destroy        = "not_renewal"
 }
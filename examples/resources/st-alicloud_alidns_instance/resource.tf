@@ -3,6 +3,7 @@ resource "st-alicloud_alidns_instance" "dns_instance" {
 	payment_type   = "Subscription"
 	period         = 1
 	renewal_status = "ManualRenewal"
+	on_destroy     = "keep"
 	version_code   = "version_enterprise_basic"
 	dns_security   = "no"
 }
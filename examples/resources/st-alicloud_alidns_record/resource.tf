@@ -0,0 +1,19 @@
+resource "st-alicloud_alidns_record" "blue" {
+  domain_name = "example.com"
+  rr          = "www"
+  type        = "A"
+  value       = "192.0.2.10"
+  ttl         = 600
+  remark      = "blue"
+  weight      = 90
+}
+
+resource "st-alicloud_alidns_record" "green" {
+  domain_name = "example.com"
+  rr          = "www"
+  type        = "A"
+  value       = "192.0.2.20"
+  ttl         = 600
+  remark      = "green"
+  weight      = 10
+}
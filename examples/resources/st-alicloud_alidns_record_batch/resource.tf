@@ -0,0 +1,28 @@
+resource "st-alicloud_alidns_record_batch" "example" {
+  domain_name = "example.com"
+  records = [
+    for i in range(1, 201) : {
+      rr    = "host${i}"
+      type  = "A"
+      value = "192.0.2.${i}"
+    }
+  ]
+}
+
+resource "st-alicloud_alidns_record_batch" "mail" {
+  domain_name = "example.com"
+  records = [
+    {
+      rr       = "@"
+      type     = "MX"
+      value    = "mx1.example.com"
+      priority = 10
+    },
+    {
+      rr    = "@"
+      type  = "TXT"
+      value = "v=spf1 mx ~all"
+      ttl   = 3600
+    },
+  ]
+}
@@ -2,3 +2,11 @@ resource "st-alicloud_alidns_record_weight" "def" {
   id = "123456789012345678"
   weight = "30"
 }
+
+resource "st-alicloud_alidns_record_weight" "ghi" {
+  domain_name = "example.com"
+  rr          = "www"
+  type        = "A"
+  value       = "192.0.2.10"
+  weight      = 70
+}
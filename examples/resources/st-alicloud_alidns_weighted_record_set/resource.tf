@@ -0,0 +1,15 @@
+resource "st-alicloud_alidns_weighted_record_set" "www" {
+  domain_name = "example.com"
+  rr          = "www"
+  type        = "A"
+
+  weights = {
+    "192.0.2.10" = 10 # blue
+    "192.0.2.20" = 90 # green
+  }
+
+  gradual_shift = {
+    steps            = 4
+    interval_seconds = 300
+  }
+}
@@ -0,0 +1,15 @@
+resource "st-alicloud_alidns_zone" "example" {
+  domain_name = "example.com"
+  exclusive   = false
+  zone_file   = <<-EOT
+    $ORIGIN example.com.
+    $TTL 600
+    @         IN A     192.0.2.1
+    www       IN CNAME @
+    @         IN MX    10 mail
+    mail      IN A     192.0.2.25
+    @         IN TXT   "v=spf1 mx ~all"
+    _sip._tcp IN SRV   0 5 5060 sip
+    @         IN CAA   0 issue "letsencrypt.org"
+  EOT
+}
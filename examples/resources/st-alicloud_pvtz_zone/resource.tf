@@ -0,0 +1,5 @@
+resource "st-alicloud_pvtz_zone" "example" {
+  zone_name     = "example.internal"
+  remark        = "Internal service discovery"
+  proxy_pattern = "ZONE"
+}
@@ -0,0 +1,17 @@
+resource "st-alicloud_pvtz_zone_record" "api_blue" {
+  zone_id = st-alicloud_pvtz_zone.example.zone_id
+  rr      = "api"
+  type    = "A"
+  value   = "10.0.0.10"
+  ttl     = 60
+  weight  = 80
+}
+
+resource "st-alicloud_pvtz_zone_record" "api_green" {
+  zone_id = st-alicloud_pvtz_zone.example.zone_id
+  rr      = "api"
+  type    = "A"
+  value   = "10.0.0.20"
+  ttl     = 60
+  weight  = 20
+}
@@ -0,0 +1,4 @@
+resource "st-alicloud_pvtz_zone_vpc_attachment" "example" {
+  zone_id = st-alicloud_pvtz_zone.example.zone_id
+  vpc_id  = "vpc-abc12345"
+}
@@ -0,0 +1,15 @@
+resource "st-alicloud_ram_access_key_rotation" "ci_user" {
+  user_name     = "test-ci-user"
+  rotation_days = 90
+  grace_period  = 7
+  pgp_key       = file("${path.module}/ci-user.pub.asc")
+}
+
+output "access_key_id" {
+  value = st-alicloud_ram_access_key_rotation.ci_user.access_key_id
+}
+
+# Decrypt with: terraform output -raw encrypted_secret | base64 --decode | gpg --decrypt
+output "encrypted_secret" {
+  value = st-alicloud_ram_access_key_rotation.ci_user.encrypted_secret
+}
@@ -0,0 +1,4 @@
+resource "st-alicloud_ram_group_members" "ram_group" {
+  group_name = "test-group"
+  user_names = ["test-user-1", "test-user-2"]
+}
@@ -0,0 +1,28 @@
+data "st-alicloud_ram_policy_document" "trust_policy" {
+  statement {
+    actions = ["sts:AssumeRole"]
+
+    principals {
+      type        = "Service"
+      identifiers = ["ecs.aliyuncs.com"]
+    }
+  }
+}
+
+resource "st-alicloud_ram_role" "ecs_role" {
+  role_name                   = "test-ecs-role"
+  assume_role_policy_document = data.st-alicloud_ram_policy_document.trust_policy.json
+  max_session_duration        = 3600
+  description                 = "Role assumed by ECS instances."
+
+  managed_policies = [
+    {
+      policy_name = "AliyunOSSReadOnlyAccess"
+      policy_type = "System"
+    },
+    {
+      policy_name = "test-custom-policy"
+      policy_type = "Custom"
+    },
+  ]
+}
@@ -0,0 +1,14 @@
+resource "st-alicloud_ram_security_preference" "account" {
+  allow_user_to_manage_mfa_devices = true
+  enable_save_mfa_ticket           = false
+  login_session_duration           = 8
+
+  minimum_password_length      = 14
+  require_lowercase_characters = true
+  require_uppercase_characters = true
+  require_numbers              = true
+  require_symbols              = true
+  max_password_age             = 90
+  password_reuse_prevention    = 5
+  max_login_attempts           = 5
+}
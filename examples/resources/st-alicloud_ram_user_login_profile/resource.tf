@@ -0,0 +1,12 @@
+resource "st-alicloud_ram_user_login_profile" "developer" {
+  user_name               = "test-developer"
+  password_reset_required = true
+  mfa_bind_required       = true
+  password_length         = 24
+  pgp_key                 = file("${path.module}/developer.pub.asc")
+}
+
+# Decrypt with: terraform output -raw encrypted_password | base64 --decode | gpg --decrypt
+output "encrypted_password" {
+  value = st-alicloud_ram_user_login_profile.developer.encrypted_password
+}